@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An API key of the server and its limits. A zero limit is the one of the server
+type apiKey struct {
+	Key      string  `json:"key"`
+	Name     string  `json:"name"`      // Shown in the logs instead of the key
+	Rate     float64 `json:"rate"`      // Requests per second allowed on average
+	Burst    int     `json:"burst"`     // Requests allowed at once
+	MaxCells int     `json:"max_cells"` // Most squares (height x width) of a maze accepted
+}
+
+// Who sent a request, with the limits that apply to it
+type apiClient struct {
+	id       string // key:<name of the key>, or ip:<address> without a key
+	rate     float64
+	burst    int
+	maxCells int
+}
+
+// The key of the apiClient in the context of a request
+type clientContextKey struct{}
+
+var (
+	// Returned by client for a key that isn't in the keys of the server
+	errInvalidKey = errors.New("invalid API key")
+
+	// Returned by client for a request without a key when -require-key is set
+	errKeyRequired = errors.New("an API key is required: send it as Authorization: Bearer <key>, the X-API-Key header (or gRPC\n" +
+		"metadata) or the api_key query parameter")
+)
+
+// Load the API keys of a JSON file, an array of {"key", "name", "rate", "burst", "max_cells"}
+func loadAPIKeys(path string) (map[string]apiKey, error) {
+	data, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []apiKey
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		return nil, fmt.Errorf("invalid keys file %s: %v", path, err)
+	}
+
+	keys := map[string]apiKey{}
+	for i, key := range list {
+		switch {
+		case key.Key == "":
+			return nil, fmt.Errorf("invalid keys file %s: key %d is empty", path, i+1)
+		case key.Rate < 0 || key.Burst < 0 || key.MaxCells < 0:
+			return nil, fmt.Errorf("invalid keys file %s: key %d has a negative limit", path, i+1)
+		}
+		if _, ok := keys[key.Key]; ok {
+			return nil, fmt.Errorf("invalid keys file %s: key %d is given twice", path, i+1)
+		}
+		if key.Name == "" {
+			key.Name = strconv.Itoa(i + 1)
+		}
+		keys[key.Key] = key
+	}
+
+	return keys, nil
+}
+
+// Get the API key of a request, empty if it has none
+func requestKey(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	// Browsers can't set the headers of a WebSocket handshake
+	return r.URL.Query().Get("api_key")
+}
+
+// Get the client of a request and its limits
+func (cfg ServeConfig) client(r *http.Request) (apiClient, error) {
+	return cfg.clientOf(requestKey(r), r.RemoteAddr)
+}
+
+// Get the client sending key, empty if none, from the address addr, and its limits
+func (cfg ServeConfig) clientOf(key, addr string) (apiClient, error) {
+	client := apiClient{rate: cfg.Rate, burst: cfg.Burst, maxCells: cfg.MaxCells}
+	if key != "" {
+		k, ok := cfg.keys[key]
+		if !ok {
+			return apiClient{}, errInvalidKey
+		}
+
+		client.id = "key:" + k.Name
+		if k.Rate > 0 {
+			client.rate = k.Rate
+		}
+		if k.Burst > 0 {
+			client.burst = k.Burst
+		}
+		if k.MaxCells > 0 {
+			client.maxCells = k.MaxCells
+		}
+		return client, nil
+	}
+
+	if cfg.RequireKey {
+		return apiClient{}, errKeyRequired
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	client.id = "ip:" + host
+	return client, nil
+}
+
+// Get the most squares of a maze accepted from the client of ctx, -max-cells when there is none
+func (cfg ServeConfig) maxCells(ctx context.Context) int {
+	if client, ok := ctx.Value(clientContextKey{}).(apiClient); ok {
+		return client.maxCells
+	}
+
+	return cfg.MaxCells
+}
+
+// Wrap the handler of a solving route of api: refuse the requests with an invalid or missing key, and the clients over
+// their rate limit. The client is put in the context of the requests let through
+func (cfg ServeConfig) guard(api string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client, err := cfg.client(r)
+		if err != nil {
+			cfg.metrics.failed(api, reasonUnauthorized)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="maze-solver"`)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if wait := cfg.rateLimit(api, client); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, rateLimitMessage(client, wait), http.StatusTooManyRequests)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), clientContextKey{}, client)))
+	}
+}
+
+// Take a request of client over api from its rate limit, and get zero, or how long to wait when it's over the limit
+func (cfg ServeConfig) rateLimit(api string, client apiClient) time.Duration {
+	if client.rate <= 0 {
+		return 0
+	}
+
+	wait := cfg.limiter.take(client.id, client.rate, client.burst, time.Now())
+	if wait > 0 {
+		cfg.metrics.failed(api, reasonRateLimited)
+		LOGGER.Debug("Request rate limited", "client", client.id, "api", api)
+	}
+	return wait
+}
+
+// The error message of a request of client refused by its rate limit for wait
+func rateLimitMessage(client apiClient, wait time.Duration) string {
+	return fmt.Sprintf("rate limit of %g requests per second exceeded, retry in %s", client.rate, wait.Round(time.Millisecond))
+}
+
+// Token buckets of the clients: a bucket holds burst tokens at most, gets rate tokens per second, and each request
+// takes one
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	pruned  time.Time // When the full buckets were last forgotten
+}
+
+type tokenBucket struct {
+	tokens   float64
+	last     time.Time // When tokens was last updated
+	rate     float64
+	capacity float64
+}
+
+// How often the buckets that are full again are forgotten, so the clients seen once don't pile up
+const limiterPruneInterval = time.Minute
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: map[string]*tokenBucket{}, pruned: time.Now()}
+}
+
+// Take a token of the bucket of id, and get zero, or how long to wait for a token when there is none
+func (l *rateLimiter) take(id string, rate float64, burst int, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	capacity := float64(max(burst, 1))
+	if now.Sub(l.pruned) > limiterPruneInterval {
+		for key, b := range l.buckets {
+			if b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.capacity {
+				delete(l.buckets, key)
+			}
+		}
+		l.pruned = now
+	}
+
+	b, ok := l.buckets[id]
+	if !ok {
+		b = &tokenBucket{tokens: capacity, last: now}
+		l.buckets[id] = b
+	}
+	b.rate, b.capacity = rate, capacity
+	b.tokens = min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.tokens--
+	return 0
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The outcome of one algorithm on one maze of a batch
+type BatchResult struct {
+	Maze      string    // Path of the maze file
+	Algorithm maze.Algo // Empty when the maze couldn't be loaded
+	maze.Stats
+	Err error // Why the maze wasn't solved, nil when it was
+}
+
+// Get the maze files matched by pattern: a glob, or a directory for every .txt file in it. The files are sorted
+func MazeFiles(pattern string) ([]string, error) {
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		pattern = filepath.Join(pattern, "*.txt")
+	}
+
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no maze matches %q", pattern)
+	}
+
+	return paths, nil
+}
+
+// Solve every maze matched by pattern with every algorithm of algos, one maze after the other. The outputs of a maze
+// are written to a directory named after it under cfg.OutDir, with the JSON export and HTML report named as in cfg.
+// The CSV metrics of every maze are appended to the same file. A summary table is printed once every maze is solved
+func SolveBatch(ctx context.Context, cfg Config, pattern string, algos []maze.Algo) error {
+	paths, err := MazeFiles(pattern)
+	if err != nil {
+		return err
+	}
+
+	var results []BatchResult
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			LOGGER.Warn("Batch stopped before solving every maze", "solved", len(results), "error", ctx.Err())
+			break
+		}
+
+		run := cfg.ForMaze(path)
+		LOGGER.Info("Solving maze", "maze", path, "out", run.OutDir)
+		report, err := SolveAllAlgo(ctx, run, algos)
+		if err != nil {
+			LOGGER.Error("Failed to solve maze", "maze", path, "error", err)
+			results = append(results, BatchResult{Maze: path, Err: err})
+			continue
+		}
+
+		for _, c := range report.Results {
+			results = append(results, BatchResult{Maze: path, Algorithm: c.SearchType, Stats: c.Stats, Err: c.Err})
+		}
+	}
+
+	PrintBatch(os.Stdout, results)
+	return nil
+}
+
+// Get the config of the maze at path in a batch: its outputs go to a directory named after it under OutDir, with the
+// JSON export and HTML report named as in cfg
+func (cfg Config) ForMaze(path string) Config {
+	run := cfg
+	run.Input = path
+	run.OutDir = filepath.Join(cfg.OutDir, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	if cfg.JSON != "" {
+		run.JSON = filepath.Join(run.OutDir, filepath.Base(cfg.JSON))
+	}
+	if cfg.Report != "" {
+		run.Report = filepath.Join(run.OutDir, filepath.Base(cfg.Report))
+	}
+
+	return run
+}
+
+// Print the results of a batch as an aligned table
+func PrintBatch(w io.Writer, results []BatchResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Maze\tAlgorithm\tSolved\tPath length\tPath cost\tExpanded\tTime\t")
+	for _, r := range results {
+		if r.Algorithm == "" {
+			fmt.Fprintf(tw, "%s\t-\terror: %v\t\t\t\t\t\n", r.Maze, r.Err)
+			continue
+		}
+
+		solved := "yes"
+		if r.Err != nil {
+			solved = "no: " + r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%s\t\n", r.Maze, r.Algorithm, solved, r.PathLength, r.PathCost,
+			r.Expanded, r.SolveTime.Round(time.Microsecond))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// Options of the bench command
+type BenchConfig struct {
+	Runs      int                  // Solves of every maze by every algorithm
+	Sizes     []string             // Sizes of the generated mazes, as HEIGHTxWIDTH
+	Seeds     int                  // Mazes generated for every size, with the seeds 1 to Seeds
+	Generate  maze.GenerateOptions // How the mazes are generated, the seed is set for each maze
+	Algos     []maze.Algo
+	Workers   int    // Goroutines of the parallel Dijkstra, 0 means one per CPU
+	Heuristic string // Name of the heuristic of GBFS and A*, empty means the default of each algorithm. A field is computed once per maze
+	JSON      string // Path of the JSON results, empty means no JSON
+	Force     bool
+}
+
+// The measures of one algorithm on the mazes of one size
+type BenchResult struct {
+	Algorithm     maze.Algo `json:"algorithm"`
+	Size          string    `json:"size"`
+	Solves        int       `json:"solves"`
+	MeanSeconds   float64   `json:"mean_seconds"`
+	MedianSeconds float64   `json:"median_seconds"`
+	MeanExpanded  float64   `json:"mean_expanded"`
+	MeanAllocs    float64   `json:"mean_allocs"`
+	MeanBytes     float64   `json:"mean_bytes_alloc"`
+}
+
+// Run the bench command: solve a fixed corpus of generated mazes with every algorithm, and report the measures as a
+// table and optionally as JSON
+func Bench(args []string) error {
+	var sizes, algos string
+	cfg := BenchConfig{}
+	fs := newFlagSet("bench", "", "Solve a fixed corpus of generated mazes with every algorithm, one solve at a time, and report the\n"+
+		"time, expanded nodes and allocations of each as a table.")
+	fs.IntVar(&cfg.Runs, "runs", 5, "How many times every maze is solved by every algorithm")
+	fs.StringVar(&sizes, "sizes", "51x51,101x101,201x201", "Comma separated sizes of the generated mazes, as HEIGHTxWIDTH")
+	fs.IntVar(&cfg.Seeds, "seeds", 3, "How many mazes are generated for every size, with the seeds 1 to N")
+	fs.Float64Var(&cfg.Generate.Loops, "loops", 0.05, "Fraction of the inner walls knocked down to open loops, 0 gives perfect mazes")
+	fs.BoolVar(&cfg.Generate.Weighted, "weighted", false, "Give the open squares a random cost from 1 to 9")
+	fs.StringVar(&algos, "search", "", "Comma separated algorithms to benchmark, empty means all (parallel-dijkstra is only run when given)")
+	fs.IntVar(&cfg.Workers, "workers", 0, "Goroutines of parallel-dijkstra, 0 means one per CPU")
+	fs.StringVar(&cfg.Heuristic, "heuristic", "", "The heuristic of GBFS and A*, field precomputes the exact cost to the goal once per maze and reuses it for every run")
+	fs.StringVar(&cfg.JSON, "json", "", "Write the results as JSON to this file")
+	fs.BoolVar(&cfg.Force, "force", false, "Overwrite the JSON file if it exists")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if cfg.Runs <= 0 || cfg.Seeds <= 0 {
+		return fmt.Errorf("runs and seeds must be positive, got %d and %d", cfg.Runs, cfg.Seeds)
+	}
+
+	cfg.Sizes = strings.Split(sizes, ",")
+	cfg.Algos = solve.AllAlgos
+	if algos != "" {
+		cfg.Algos = nil
+		for _, algo := range strings.Split(algos, ",") {
+			if !maze.IsAlgo(algo) {
+				return fmt.Errorf("unsupported algorithm %q", algo)
+			}
+			cfg.Algos = append(cfg.Algos, maze.Algo(algo))
+		}
+	}
+
+	if cfg.Heuristic != "" && cfg.Heuristic != "field" {
+		if _, err := solve.HeuristicByName(cfg.Heuristic); err != nil {
+			return err
+		}
+	}
+
+	results, err := RunBench(cfg)
+	if err != nil {
+		return err
+	}
+
+	PrintBench(os.Stdout, results)
+	if cfg.JSON != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err = WriteResult(cfg.JSON, data, cfg.Force); err != nil {
+			return err
+		}
+		LOGGER.Info("Write benchmark results successfully", "path", cfg.JSON)
+	}
+
+	return nil
+}
+
+// Generate the corpus and solve it, one solve at a time so the measures don't disturb each other
+func RunBench(cfg BenchConfig) ([]BenchResult, error) {
+	var results []BenchResult
+	for _, size := range cfg.Sizes {
+		var height, width int
+		if _, err := fmt.Sscanf(size, "%dx%d", &height, &width); err != nil {
+			return nil, fmt.Errorf("invalid size %q, expected HEIGHTxWIDTH: %v", size, err)
+		}
+
+		mazes := make([]*maze.Maze, cfg.Seeds)
+		heuristics := make([]solve.Heuristic, cfg.Seeds)
+		for i := range mazes {
+			opts := cfg.Generate
+			opts.Seed = int64(i + 1)
+			m, err := maze.Generate(height, width, opts)
+			if err != nil {
+				return nil, err
+			}
+			mazes[i] = m
+
+			switch cfg.Heuristic {
+			case "":
+			case "field":
+				if heuristics[i], err = solve.NewDistanceField(m); err != nil {
+					return nil, err
+				}
+			default:
+				if heuristics[i], err = solve.HeuristicByName(cfg.Heuristic); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for _, algo := range cfg.Algos {
+			LOGGER.Info("Benchmarking", "algo", algo, "size", size)
+			result := BenchResult{Algorithm: algo, Size: size}
+			var times []time.Duration
+			for i, m := range mazes {
+				for range cfg.Runs {
+					solver, err := solve.NewSolverForAlgo(algo, m, heuristics[i], solve.WithoutTrace(), solve.WithWorkers(cfg.Workers),
+						solve.WithAllocStats())
+					if err != nil {
+						return nil, err
+					}
+
+					solved, err := solver.Solve()
+					if err != nil {
+						return nil, fmt.Errorf("%s failed on a %s maze: %v", algo, size, err)
+					}
+
+					times = append(times, solved.SolveTime)
+					result.MeanSeconds += solved.SolveTime.Seconds()
+					result.MeanExpanded += float64(solved.Expanded)
+					result.MeanAllocs += float64(solved.Allocs)
+					result.MeanBytes += float64(solved.BytesAlloc)
+				}
+			}
+
+			n := float64(len(times))
+			result.Solves = len(times)
+			result.MeanSeconds /= n
+			result.MeanExpanded /= n
+			result.MeanAllocs /= n
+			result.MeanBytes /= n
+
+			slices.Sort(times)
+			median := times[len(times)/2]
+			if len(times)%2 == 0 {
+				median = (times[len(times)/2-1] + median) / 2
+			}
+			result.MedianSeconds = median.Seconds()
+
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// Print the results as an aligned table
+func PrintBench(w io.Writer, results []BenchResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Algorithm\tSize\tSolves\tMean time\tMedian time\tMean expanded\tMean allocs\tMean bytes\t")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%.0f\t%.0f\t%.0f\t\n", r.Algorithm, r.Size, r.Solves,
+			seconds(r.MeanSeconds), seconds(r.MedianSeconds), r.MeanExpanded, r.MeanAllocs, r.MeanBytes)
+	}
+	tw.Flush()
+}
+
+// Get a number of seconds as a duration, rounded to be readable in the table
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Microsecond)
+}
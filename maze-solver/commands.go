@@ -0,0 +1,629 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// A subcommand of the binary, run with the arguments that follow its name
+type Command struct {
+	Name    string
+	Summary string // One line shown in the list of commands
+	Run     func(args []string) error
+}
+
+// Every command, in the order they are listed
+var Commands = []Command{
+	{Name: "solve", Summary: "Solve a maze with one algorithm and write its outputs", Run: SolveCommand},
+	{Name: "compare", Summary: "Solve a maze with several algorithms at once and rank them", Run: CompareCommand},
+	{Name: "generate", Summary: "Generate a random maze", Run: GenerateCommand},
+	{Name: "render", Summary: "Render the image and GIF of a replay file without solving again", Run: RenderCommand},
+	{Name: "validate", Summary: "Check that a maze can be loaded and solved, without solving it", Run: ValidateCommand},
+	{Name: "stats", Summary: "Measure the shape and difficulty of a maze, without solving it", Run: StatsCommand},
+	{Name: "history", Summary: "List the recorded solves and compare them with the earlier ones", Run: HistoryCommand},
+	{Name: "bench", Summary: "Benchmark the algorithms on a corpus of generated mazes", Run: Bench},
+	{Name: "serve", Summary: "Solve mazes over HTTP", Run: Serve},
+	{Name: "worker", Summary: "Solve the mazes of the requests of a NATS subject", Run: Worker},
+	{Name: "remote", Summary: "Solve mazes on a server started with serve, and fetch the results", Run: RemoteCommand},
+}
+
+// The most squares of a maze loaded by the commands by default: a 10000x10000 maze, which takes about 112 MB
+const defaultMaxCells = 100_000_000
+
+// Get a command by its name
+func CommandByName(name string) (Command, bool) {
+	i := slices.IndexFunc(Commands, func(c Command) bool {
+		return c.Name == name
+	})
+	if i < 0 {
+		return Command{}, false
+	}
+
+	return Commands[i], true
+}
+
+// Print the usage of the binary and its list of commands
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: maze-solver [global flags] <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range Commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.Name, c.Summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	flag.CommandLine.SetOutput(w)
+	flag.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'maze-solver <command> -h' for the flags of a command")
+}
+
+// Create the flag set of a command, its help shows the usage line (with the positional arguments in args) and the
+// description of the command before the flags
+func newFlagSet(name, args, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: maze-solver %s [flags]%s\n\n%s\n\nFlags:\n", name, args, description)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// Parse the flags of a command that takes no positional argument
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q, %s only takes flags", fs.Arg(0), fs.Name())
+	}
+
+	return nil
+}
+
+// The flags shared by the commands that solve or render, turned into the Config of the run by config
+type runFlags struct {
+	cfg        Config
+	theme      string
+	renderMode string
+	png, gif   bool
+	noOutput   bool
+	format     string // Comma separated artifacts to write, the default depends on the command
+	heuristic  string
+	movement   string
+	gifMB      int64
+}
+
+func newRunFlags() *runFlags {
+	return &runFlags{cfg: Config{Render: render.DefaultRenderOptions()}, renderMode: "image", movement: "four", format: "png,gif"}
+}
+
+// Add the flags of the maze, the solvers and what is exported from them
+func (f *runFlags) solverFlags(fs *flag.FlagSet) {
+	cfg := &f.cfg
+	fs.StringVar(&cfg.Input, "maze", "mazes/maze.txt", "The maze input file, - reads it from stdin")
+	fs.BoolVar(&cfg.Strict, "strict", false, "Refuse a maze whose rows have different lengths instead of padding the short rows with walls")
+	fs.IntVar(&cfg.MaxCells, "max-cells", defaultMaxCells, "Refuse a maze with more squares (height x width) than this before loading it, 0 means no limit")
+	fs.StringVar(&f.renderMode, "render", f.renderMode, "How to output the solved maze: image (PNG and GIF files), ascii or emoji (printed to the terminal)")
+	fs.DurationVar(&cfg.Timeout, "timeout", 0, "Stop each solve after this long (e.g. 30s) and report the partial statistics, 0 means no limit")
+	fs.StringVar(&f.heuristic, "heuristic", "", "The heuristic of GBFS and A*: manhattan, euclidean, chebyshev, octile, zero or field (the exact cost to the goal, precomputed once per maze). Empty means manhattan for GBFS, and manhattan (four) or chebyshev (eight) for A*")
+	fs.StringVar(&f.movement, "movement", f.movement, "How the solvers move: four (up, down, left, right) or eight (diagonals too)")
+	fs.BoolVar(&cfg.Allocs, "allocs", false, "Measure the heap allocations of every solve. The Go runtime counts them for the whole\n"+
+		"process, so they include what runs at the same time, like the other algorithms of -all and their outputs")
+	fs.IntVar(&cfg.Workers, "workers", 0, "Goroutines expanding the nodes of parallel-dijkstra, 0 means one per CPU")
+	fs.IntVar(&cfg.Runs, "runs", 1, "Solve this many times, one run after the other, and report the mean and standard deviation of\n"+
+		"the solve time and whether every run found the same path. The outputs come from the first run")
+	fs.IntVar(&cfg.MaxNodes, "max-nodes", 0, "Stop each solve after expanding this many nodes and report the partial statistics, 0 means no limit")
+	fs.StringVar(&cfg.JSON, "json", "", "Write the solution and statistics as JSON to this file")
+	fs.StringVar(&cfg.CSV, "csv", "", "Append one row of metrics per algorithm to this CSV file")
+	fs.StringVar(&cfg.History, "history", "", "Record every solve (maze hash, algorithm, options, statistics and output files) into\n"+
+		"this SQLite database, to be listed and compared with the history command")
+	fs.BoolVar(&cfg.DOT, "dot", false, "Export the search tree of each algorithm as a GraphViz DOT file")
+	fs.BoolVar(&cfg.Replay, "replay", false, "Save the solver trace of each algorithm as a replay file, which can be rendered later with the render command")
+	fs.BoolVar(&cfg.Verify, "verify", false, "Check that every solution is a valid path from the start to the goal")
+	fs.BoolVar(&cfg.Optimal, "check-optimal", false, "Compare the path cost of every algorithm with the optimal one, found by BFS on unweighted\n"+
+		"mazes and Dijkstra on weighted ones, and report the paths that cost more and by how much")
+	fs.StringVar(&cfg.Report, "report", "", "Write a self-contained HTML report with images, animations and statistics to this file")
+	fs.BoolVar(&cfg.DryRun, "dry-run", false, "Only print the files that would be written, the image sizes and the most GIF frames, without solving")
+	fs.BoolVar(&f.noOutput, "no-output", false, "Write no PNG, GIF or SVG image in the image render mode, only the outputs of the other flags")
+	fs.BoolVar(&cfg.NoTrace, "no-trace", false, "Only report the path length, path cost and statistics, as fast as possible: the solver trace\n"+
+		"isn't recorded and no image, GIF or text render is written. Can't be used with the outputs that need the trace")
+}
+
+// Add the flags of where and how the output files are written
+func (f *runFlags) outputFlags(fs *flag.FlagSet) {
+	cfg := &f.cfg
+	fs.StringVar(&cfg.OutDir, "out", ".", "The directory to write the output files into")
+	fs.BoolVar(&cfg.Force, "force", false, "Overwrite existing output files")
+	fs.StringVar(&cfg.Name, "name", DefaultNameTemplate, "Template of the output file names, supports {maze}, {algo}, {ext}, {timestamp} and {date}")
+	fs.BoolVar(&maze.Deterministic, "deterministic", false, "Produce byte-identical outputs and logs on every run: fixed tie-breaking, no timings, a fixed timestamp\n"+
+		"and the algorithms of -all solved one at a time")
+}
+
+// Add the flags of the images and GIFs
+func (f *runFlags) renderFlags(fs *flag.FlagSet) {
+	opts := &f.cfg.Render
+	fs.StringVar(&f.format, "format", f.format, "Comma separated artifacts to write: png, gif, svg and json (named after the maze, unless -json\n"+
+		"is set). Only used in the image render mode")
+	fs.BoolVar(&f.png, "png", false, "Write the solution image. With -png or -gif, only the images they name are written, whatever -format says")
+	fs.BoolVar(&f.gif, "gif", false, "Write the solving animation. With -png or -gif, only the images they name are written, whatever -format says")
+	fs.StringVar(&f.theme, "theme", "light", "The color theme of the output: light, dark, colorblind or path to a custom JSON theme")
+	fs.IntVar(&opts.CellSize, "cell-size", opts.CellSize, "The size (in pixel) of each square in the output")
+	fs.IntVar(&opts.BorderWidth, "border-width", opts.BorderWidth, "The width (in pixel) of the border around the maze")
+	fs.IntVar(&opts.MaxImagePixels, "max-image-px", opts.MaxImagePixels, "The pixel budget of the maze image, the cell size is reduced to fit it. 0 means no limit")
+	fs.BoolVar(&opts.GridLines, "grid", false, "Draw grid lines between squares")
+	fs.BoolVar(&opts.FrameCounters, "counters", false, "Draw the step, frontier size and explored count on each GIF frame")
+	fs.BoolVar(&opts.SolutionOnly, "solution-only", false, "Only draw the maze and the solution path in images, without the explored squares")
+	fs.BoolVar(&opts.Transparent, "transparent", false, "Render the solution image on a transparent background")
+	fs.BoolVar(&opts.ExpansionOrder, "numbers", false, "Print the expansion order inside each explored square of the image (small mazes only)")
+	fs.BoolVar(&opts.Legend, "legend", false, "Draw the color legend and solving stats under the maze")
+	fs.IntVar(&opts.FrameStride, "frame-stride", opts.FrameStride, "Only every Nth solver step becomes a GIF frame")
+	fs.IntVar(&opts.MaxFrames, "max-frames", opts.MaxFrames, "The maximum number of GIF frames, the frame stride is increased to stay under it. 0 means no limit")
+	fs.DurationVar(&opts.FrameDelay, "frame-delay", opts.FrameDelay, "How long each step of the GIF is shown")
+	fs.DurationVar(&opts.FinalHold, "final-hold", opts.FinalHold, "How long the final GIF frame with the solution is shown")
+	fs.Float64Var(&opts.Speed, "speed", opts.Speed, "Playback speedup of the GIF, applied to -frame-delay and -final-hold: 2 plays twice as fast.\n"+
+		"The delays are rounded to 10ms, the precision of GIFs")
+	fs.Int64Var(&f.gifMB, "max-gif-mb", opts.MaxGIFBytes>>20, "The memory budget of a GIF in MB, estimated as frames x frame size. Frames are dropped to fit it, 0 means no limit")
+	fs.BoolVar(&opts.PathGradient, "path-gradient", opts.PathGradient, "Color the solution path by accumulated cost on weighted mazes")
+}
+
+// Check the parsed flags and build the config of the run
+func (f *runFlags) config() (Config, error) {
+	cfg := f.cfg
+	cfg.Start = maze.Now()
+	cfg.Render.MaxGIFBytes = f.gifMB << 20
+
+	if f.noOutput && (f.png || f.gif) {
+		return cfg, fmt.Errorf("-no-output can't be used with -png or -gif")
+	}
+	if cfg.NoTrace {
+		if f.png || f.gif || f.renderMode != "image" || cfg.Replay || cfg.Report != "" || cfg.Live > 0 || cfg.Stdout != "" {
+			return cfg, fmt.Errorf("-no-trace can't be used with -png, -gif, -render, -replay, -report, -live or -stdout")
+		}
+		f.noOutput = true
+	}
+	if cfg.History != "" && historyDriver == "" {
+		return cfg, errNoSQLite
+	}
+
+	formats := map[string]bool{}
+	for _, format := range strings.Split(f.format, ",") {
+		if format = strings.TrimSpace(strings.ToLower(format)); format == "" {
+			continue
+		}
+		if !slices.Contains([]string{"png", "gif", "svg", "json"}, format) {
+			return cfg, fmt.Errorf("unknown format %q, supported: png, gif, svg, json", format)
+		}
+		formats[format] = true
+	}
+
+	cfg.PNG, cfg.GIF, cfg.SVG = formats["png"], formats["gif"], formats["svg"]
+	if f.png || f.gif {
+		cfg.PNG, cfg.GIF, cfg.SVG = f.png, f.gif, false
+	} else if f.noOutput {
+		cfg.PNG, cfg.GIF, cfg.SVG = false, false, false
+	}
+	if formats["json"] && cfg.JSON == "" {
+		cfg.JSON = cfg.ResultFilename("results", "json")
+	}
+
+	var err error
+	if cfg.Render.Theme, err = render.LoadTheme(f.theme); err != nil {
+		return cfg, fmt.Errorf("failed to load theme: %v", err)
+	}
+
+	if f.renderMode != "image" {
+		style, err := render.LoadTextStyle(f.renderMode)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid render mode: %v", err)
+		}
+		cfg.Text = &style
+	}
+
+	if cfg.Topology, err = maze.TopologyByName(f.movement); err != nil {
+		return cfg, err
+	}
+
+	if f.heuristic == "field" {
+		cfg.Field = true
+	} else if f.heuristic != "" {
+		if cfg.Heuristic, err = solve.HeuristicByName(f.heuristic); err != nil {
+			return cfg, err
+		}
+	}
+
+	if err = cfg.Render.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid render options: %v", err)
+	}
+
+	if err = ValidateNameTemplate(cfg.Name); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
+// Warn when A* is one of algos and its heuristic may overestimate with the movement of the run, since A* only finds
+// the shortest path with a heuristic that never overestimates
+func (f *runFlags) warnInadmissible(cfg Config, algos []maze.Algo) {
+	if cfg.Field || !slices.Contains(algos, maze.ASTAR) {
+		return
+	}
+
+	// The default heuristic of A* is admissible with every movement
+	h := cfg.Heuristic
+	if h != nil && !solve.Admissible(h, cfg.Topology) {
+		LOGGER.Warn("The heuristic is not admissible with this movement, A* may not find the shortest path",
+			"heuristic", h.Name(), "movement", f.movement)
+	}
+}
+
+// The context of a command, which is cancelled by Ctrl+C or once the global -timeout is over. The solving stops, the
+// partial results are still written
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if DEADLINE.IsZero() {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, DEADLINE)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// Run the solve command
+func SolveCommand(args []string) error {
+	f := newRunFlags()
+	var search, input string
+	var all bool
+	fs := newFlagSet("solve", "", "Solve a maze with one algorithm, log its statistics and write the outputs asked by the flags.\n"+
+		"With the image render mode, the artifacts of -format are written unless -no-output is set.\n"+
+		"With -input, every matching maze is solved, its outputs are written into a directory named after it\n"+
+		"under -out, and a summary table is printed at the end.")
+	fs.StringVar(&search, "search", string(maze.ASTAR), "The search algorithm: dfs, bfs, dijkstra, parallel-dijkstra, gbfs or astar")
+	fs.StringVar(&input, "input", "", "Solve every maze matching this glob, e.g. 'mazes/*.txt', or every .txt file of this directory, instead of -maze")
+	fs.BoolVar(&all, "all", false, "Solve every maze of -input with dfs, bfs, dijkstra, gbfs and astar instead of -search")
+	fs.IntVar(&f.cfg.Parallel, "parallelism", 0, "How many algorithms solve a maze of -input at the same time with -all, 0 means all of them")
+	fs.DurationVar(&f.cfg.Live, "live", 0, "Animate the solving in the terminal while it happens, waiting this long per step, e.g. 50ms")
+	fs.BoolVar(&f.cfg.Explain, "explain", false, "Narrate the solve step by step: which square is expanded and why, and which neighbors are\n"+
+		"added to the frontier. Meant for small mazes, only the first steps of a large one are narrated")
+	fs.StringVar(&f.cfg.Snapshot, "snapshot", "", "Save the state of a solve stopped by -timeout (global or of the command), -max-nodes or Ctrl+C to this file")
+	fs.StringVar(&f.cfg.Resume, "resume", "", "Carry on the solve saved in this snapshot file instead of starting over")
+	fs.StringVar(&f.cfg.Stdout, "stdout", "", "Write only this result to stdout, for pipes: json, ascii (or the text of -render) or png.\n"+
+		"The PNG, GIF and text outputs are not written, and the logs go to stderr. Use with -maze - to read the maze from stdin")
+	f.solverFlags(fs)
+	f.outputFlags(fs)
+	f.renderFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if !maze.IsAlgo(search) {
+		return fmt.Errorf("unsupported algorithm %q", search)
+	}
+	algo := maze.Algo(search)
+
+	// Without -search, a single maze solved in a terminal asks the algorithm and the images
+	if input == "" && f.cfg.Stdout == "" {
+		picked, err := f.pickInteractively(fs, []maze.Algo{algo}, false)
+		if err != nil {
+			return err
+		}
+		algo = picked[0]
+	}
+
+	algos := []maze.Algo{algo}
+	if all {
+		algos = solve.AllAlgos
+	}
+
+	if input == "" && all {
+		return fmt.Errorf("-all only works with -input, use the compare command to solve one maze with every algorithm")
+	}
+	if input != "" && (f.cfg.Live > 0 || f.cfg.Snapshot != "" || f.cfg.Resume != "") {
+		return fmt.Errorf("-live, -snapshot and -resume only work on a single maze, not with -input")
+	}
+	// A GIF per maze and algorithm would make a batch slow
+	if input != "" && !isFlagSet(fs, "format") {
+		f.format = "png"
+	}
+	if input != "" && f.cfg.Runs > 1 {
+		return fmt.Errorf("-runs only works on a single maze, not with -input")
+	}
+	if f.cfg.Runs > 1 && (f.cfg.Live > 0 || f.cfg.Resume != "") {
+		return fmt.Errorf("-runs can't be used with -live or -resume, which only solve once")
+	}
+	if f.cfg.Explain && (input != "" || f.cfg.Live > 0 || f.cfg.Stdout != "") {
+		return fmt.Errorf("-explain only works on a single maze, without -input, -live and -stdout")
+	}
+
+	if f.cfg.Stdout != "" {
+		if !slices.Contains([]string{"json", "ascii", "png"}, f.cfg.Stdout) {
+			return fmt.Errorf("unknown stdout result %q, supported: json, ascii, png", f.cfg.Stdout)
+		}
+		if input != "" || f.cfg.Live > 0 || f.png || f.gif {
+			return fmt.Errorf("-stdout only works on a single maze, without -input, -live, -png and -gif")
+		}
+
+		// Nothing but the result goes to stdout
+		LOG_OUTPUT.Writer = os.Stderr
+		f.noOutput = true
+	}
+
+	cfg, err := f.config()
+	if err != nil {
+		return err
+	}
+	f.warnInadmissible(cfg, algos)
+
+	if cfg.DryRun {
+		runs := []Config{cfg}
+		if input != "" {
+			paths, err := MazeFiles(input)
+			if err != nil {
+				return err
+			}
+
+			runs = nil
+			for _, path := range paths {
+				runs = append(runs, cfg.ForMaze(path))
+			}
+		}
+		return DryRun(os.Stdout, runs, algos)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	if input != "" {
+		return SolveBatch(ctx, cfg, input, algos)
+	}
+
+	return SolveOne(ctx, cfg, algo)
+}
+
+// Run the compare command
+func CompareCommand(args []string) error {
+	f := newRunFlags()
+	var search string
+	fs := newFlagSet("compare", "", "Solve a maze with several algorithms at the same time, print a table of their statistics and a verdict\n"+
+		"(cheapest path, fewest expanded nodes, fastest, ranking and recommendation), and write the outputs of\n"+
+		"every algorithm asked by the flags. -sheet adds an image of every solution side by side.")
+	fs.StringVar(&search, "search", "", "Comma separated algorithms to compare, empty means dfs, bfs, dijkstra, gbfs and astar")
+	fs.IntVar(&f.cfg.Parallel, "parallelism", 0, "How many algorithms solve and write their outputs at the same time, 0 means all of them. Lower it on big mazes to bound the CPU and memory used")
+	fs.BoolVar(&f.cfg.Sheet, "sheet", false, "Create a single PNG comparing the solution of every algorithm")
+	f.solverFlags(fs)
+	f.outputFlags(fs)
+	// A GIF per algorithm is slow, so compare only writes the images by default
+	f.format = "png"
+	f.renderFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	algos := solve.AllAlgos
+	if search != "" {
+		algos = nil
+		for _, algo := range strings.Split(search, ",") {
+			if !maze.IsAlgo(algo) {
+				return fmt.Errorf("unsupported algorithm %q", algo)
+			}
+			algos = append(algos, maze.Algo(algo))
+		}
+	}
+
+	// Without -search in a terminal, ask the algorithms and the images instead of running all of them
+	algos, err := f.pickInteractively(fs, algos, true)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := f.config()
+	if err != nil {
+		return err
+	}
+	f.warnInadmissible(cfg, algos)
+
+	if cfg.DryRun {
+		return DryRun(os.Stdout, []Config{cfg}, algos)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	report, err := SolveAllAlgo(ctx, cfg, algos)
+	if err != nil {
+		return err
+	}
+
+	PrintComparison(os.Stdout, report)
+	fmt.Println()
+	PrintVerdict(os.Stdout, report)
+	return nil
+}
+
+// Run the render command
+func RenderCommand(args []string) error {
+	f := newRunFlags()
+	fs := newFlagSet("render", " <replay file>", "Render the image and GIF of a replay file saved by solve -replay or compare -replay,\n"+
+		"without solving the maze again.")
+	f.outputFlags(fs)
+	f.renderFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("render takes one replay file, got %d arguments", fs.NArg())
+	}
+
+	// The output files are named after the replay file
+	f.cfg.Input = fs.Arg(0)
+
+	cfg, err := f.config()
+	if err != nil {
+		return err
+	}
+
+	return RenderReplay(cfg, fs.Arg(0))
+}
+
+// Run the generate command
+func GenerateCommand(args []string) error {
+	var size, output string
+	var force bool
+	opts := maze.GenerateOptions{}
+	fs := newFlagSet("generate", "", "Generate a random maze with a randomized depth-first search, and print it or write it to a file.\n"+
+		"The start is the top-left room and the goal the bottom-right one.")
+	fs.StringVar(&size, "size", "21x21", "The size of the maze, as HEIGHTxWIDTH")
+	fs.Int64Var(&opts.Seed, "seed", 1, "The seed of the maze, the same seed always gives the same maze")
+	fs.Float64Var(&opts.Loops, "loops", 0, "Fraction of the inner walls knocked down to open loops, 0 gives a perfect maze")
+	fs.BoolVar(&opts.Weighted, "weighted", false, "Give the open squares a random cost from 1 to 9")
+	fs.StringVar(&output, "output", "", "Write the maze to this file instead of printing it")
+	fs.BoolVar(&force, "force", false, "Overwrite the output file if it exists")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var height, width int
+	if _, err := fmt.Sscanf(size, "%dx%d", &height, &width); err != nil {
+		return fmt.Errorf("invalid size %q, expected HEIGHTxWIDTH: %v", size, err)
+	}
+
+	m, err := maze.Generate(height, width, opts)
+	if err != nil {
+		return err
+	}
+	// The text format has no symbol for a square that is both the start and the goal
+	if m.Start == m.Goal {
+		return fmt.Errorf("a %dx%d maze has a single room, which is both its start and its goal, it needs at least 5 rows or 5 columns",
+			height, width)
+	}
+
+	text := strings.Join(m.Rows(), "\n") + "\n"
+	if output == "" {
+		fmt.Print(text)
+		return nil
+	}
+
+	if err = WriteResult(output, []byte(text), force); err != nil {
+		return err
+	}
+
+	LOGGER.Info("Generate maze successfully", "path", output)
+	return nil
+}
+
+// Run the validate command
+func ValidateCommand(args []string) error {
+	var input, movement string
+	fs := newFlagSet("validate", "", "Load a maze and check that it can be solved, without running any solver. Every problem found is\n"+
+		"printed with its row and column: invalid characters, missing or duplicate start and goal, rows of\n"+
+		"different lengths, unsupported costs and a goal that can't be reached from the start.")
+	fs.StringVar(&input, "maze", "mazes/maze.txt", "The maze input file, - reads it from stdin")
+	fs.StringVar(&movement, "movement", "four", "How the solvers move, to check that the goal can be reached: four or eight")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	topology, err := maze.TopologyByName(movement)
+	if err != nil {
+		return err
+	}
+
+	data, err := ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("failed to read maze: %v", err)
+	}
+
+	m, diags := maze.Diagnose(data, topology)
+	errs := 0
+	for _, d := range diags {
+		fmt.Printf("%s: %s\n", input, d)
+		if d.Severity == maze.SeverityError {
+			errs++
+		}
+	}
+	if errs > 0 {
+		return fmt.Errorf("%s is not a valid maze, %d error(s) found", input, errs)
+	}
+
+	fmt.Printf("%s: valid %dx%d maze, %d open squares, start at row %d column %d, goal at row %d column %d\n",
+		input, m.Height, m.Width, m.GetEmptySquares(), m.Start.Row, m.Start.Col, m.Goal.Row, m.Goal.Col)
+	return nil
+}
+
+// Run the stats command
+func StatsCommand(args []string) error {
+	var input, movement string
+	var asJSON bool
+	var maxCells int
+	fs := newFlagSet("stats", "", "Measure the shape of a maze without solving it: dead ends, junctions, branching factor, loops,\n"+
+		"longest corridor, wall density and a rough difficulty score from 0 to 100.")
+	fs.StringVar(&input, "maze", "mazes/maze.txt", "The maze input file, - reads it from stdin")
+	fs.StringVar(&movement, "movement", "four", "How the solvers move, which decides the neighbors of each square: four or eight")
+	fs.BoolVar(&asJSON, "json", false, "Print the measures as JSON instead of text")
+	fs.IntVar(&maxCells, "max-cells", defaultMaxCells, "Refuse a maze with more squares (height x width) than this before loading it, 0 means no limit")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	topology, err := maze.TopologyByName(movement)
+	if err != nil {
+		return err
+	}
+
+	data, err := ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("failed to read maze: %v", err)
+	}
+
+	m := &maze.Maze{Topology: topology, MaxCells: maxCells}
+	if err := m.Load(data); err != nil {
+		return err
+	}
+
+	a := m.Analyze()
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(a)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Maze\t%s\n", input)
+	fmt.Fprintf(tw, "Size\t%dx%d\n", a.Height, a.Width)
+	fmt.Fprintf(tw, "Open squares\t%d\n", a.OpenSquares)
+	fmt.Fprintf(tw, "Wall density\t%.1f%%\n", 100*a.WallDensity)
+	fmt.Fprintf(tw, "Dead ends\t%d\n", a.DeadEnds)
+	fmt.Fprintf(tw, "Junctions\t%d\n", a.Junctions)
+	fmt.Fprintf(tw, "Branching factor\t%.2f\n", a.BranchingFactor)
+	fmt.Fprintf(tw, "Loops\t%d\n", a.Loops)
+	fmt.Fprintf(tw, "Longest corridor\t%d\n", a.LongestCorridor)
+	if a.Reachable {
+		fmt.Fprintf(tw, "Shortest route\t%d moves\n", a.ShortestSteps)
+	} else {
+		fmt.Fprintf(tw, "Shortest route\tnone, the goal can't be reached\n")
+	}
+	fmt.Fprintf(tw, "Difficulty\t%d/100\n", a.Difficulty)
+	return tw.Flush()
+}
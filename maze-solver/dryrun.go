@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+)
+
+// Print what the runs would write with every algorithm of algos, without solving or rendering anything: the files, the
+// image sizes and the most GIF frames. The mazes are only loaded. There is one run per maze, see Config.ForMaze
+func DryRun(w io.Writer, runs []Config, algos []maze.Algo) error {
+	for _, run := range runs {
+		data, err := ReadFile(run.Input)
+		if err != nil {
+			return fmt.Errorf("failed to read maze: %v", err)
+		}
+
+		// Load without LoadMaze, which would precompute the distance field
+		m := &maze.Maze{Topology: run.Topology}
+		if err := m.Load(data); err != nil {
+			return err
+		}
+		if err := m.Validate(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "Maze %s: %dx%d, %d open squares\n", run.Input, m.Height, m.Width, m.GetEmptySquares())
+		if run.Text != nil {
+			fmt.Fprintln(w, "  The solved maze is printed as text")
+		}
+		if run.Stdout != "" {
+			fmt.Fprintf(w, "  stdout: %s\n", run.Stdout)
+		}
+
+		for _, algo := range algos {
+			images := run.Text == nil && run.Stdout == ""
+			if images && run.PNG {
+				plan, _ := render.PlanImages(m, 0, run.Render)
+				planned(w, run, run.ResultFilename(string(algo), "png"), fmt.Sprintf("%dx%d pixels, cell size %d",
+					plan.ImageWidth, plan.ImageHeight, plan.CellSize))
+			}
+			if images && run.SVG {
+				planned(w, run, run.ResultFilename(string(algo), "svg"), "")
+			}
+			if images && run.GIF {
+				// Every expanded square is at least one step of the trace, and DFS steps back over the squares it
+				// has left
+				steps := m.GetEmptySquares()
+				if algo == maze.DFS {
+					steps *= 2
+				}
+
+				plan, err := render.PlanImages(m, steps, run.Render)
+				detail := fmt.Sprintf("at most %d frames of %dx%d pixels, about %.1f MB to encode", plan.GIFFrames,
+					plan.GIFWidth, plan.GIFHeight, float64(plan.GIFBytes)/(1<<20))
+				if err != nil {
+					detail = "refused: " + err.Error()
+				}
+				planned(w, run, run.ResultFilename(string(algo), "gif"), detail)
+			}
+			if run.DOT {
+				planned(w, run, run.ResultFilename(string(algo), "dot"), "")
+			}
+			if run.Replay {
+				planned(w, run, run.ResultFilename(string(algo), "replay"), "")
+			}
+		}
+
+		if run.JSON != "" {
+			planned(w, run, run.JSON, "")
+		}
+		if run.Report != "" {
+			planned(w, run, run.Report, "")
+		}
+		if run.CSV != "" {
+			fmt.Fprintf(w, "  %s (rows appended)\n", run.CSV)
+		}
+		if run.History != "" {
+			fmt.Fprintf(w, "  %s (runs recorded)\n", run.History)
+		}
+		if run.Sheet {
+			planned(w, run, run.ResultFilename("comparison", "png"), "")
+		}
+		if run.Snapshot != "" {
+			planned(w, run, run.Snapshot, "only if the solve stops before finishing")
+		}
+	}
+
+	return nil
+}
+
+// Print a file a run would write, with a detail if not empty, and a warning if it exists and can't be overwritten
+func planned(w io.Writer, cfg Config, path, detail string) {
+	line := "  " + path
+	if detail != "" {
+		line += " (" + detail + ")"
+	}
+	if _, err := os.Stat(path); err == nil && !cfg.Force {
+		line += " [exists, use -force to overwrite it]"
+	}
+
+	fmt.Fprintln(w, line)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// The most expansions narrated by -explain, the rest of a solve is only counted
+const explainMaxSteps = 500
+
+// Solve the maze while narrating it step by step, see narrate
+func SolveExplained(ctx context.Context, cfg Config, solver solve.Solver, m *maze.Maze, algo maze.Algo) *maze.Solved {
+	heuristic := ""
+	if h := solve.HeuristicOf(solver); h != nil {
+		heuristic = h.Name()
+	}
+
+	events := solver.Events()
+	narrated := make(chan struct{})
+	go func() {
+		defer close(narrated)
+		narrate(LOG_OUTPUT, m, algo, heuristic, events)
+	}()
+
+	// The solve is logged once the narration is over, so they don't interleave
+	result, err := solver.SolveContext(ctx)
+	<-narrated
+	solved := &maze.Solved{Maze: m, SearchType: algo, Heuristic: heuristic, Result: result}
+	LogSolved(solved, err, !cfg.NoTrace)
+	return solved
+}
+
+// Print a line for every node expanded by the solve of events: the node, why the algorithm expands it before the
+// others, and what it adds to the frontier. The line of a node is printed once the next one is expanded, when
+// everything it has added is known. Only the first explainMaxSteps nodes are narrated
+func narrate(w io.Writer, m *maze.Maze, algo maze.Algo, heuristic string, events <-chan solve.SearchEvent) {
+	fmt.Fprintf(w, "Explaining %s from %s to %s: %s\n\n", algo, explainPoint(m.Start), explainPoint(m.Goal),
+		explainRule(algo, heuristic))
+
+	var (
+		steps    int
+		current  *solve.SearchEvent // The expanded node being narrated, nil before the first one
+		added    []string           // What current has added to the frontier
+		frontier int                // Size of the frontier after the last event
+		expanded = map[maze.Point]bool{}
+	)
+	flush := func() {
+		if current == nil || steps > explainMaxSteps {
+			return
+		}
+
+		why := explainReason(algo)
+		switch {
+		case current.Point == m.Start && steps == 1:
+			why = "the start"
+		case expanded[current.Point]:
+			why += ", again as a cheaper path to it was found"
+		}
+		expanded[current.Point] = true
+
+		fmt.Fprintf(w, "%4d. Expand %s, %s", steps, explainNode(algo, *current), why)
+		if current.Point == m.Goal {
+			fmt.Fprintln(w, ". It's the goal, the search is over")
+			return
+		}
+		if len(added) == 0 {
+			fmt.Fprint(w, ". Nothing new to add to the frontier")
+		} else {
+			fmt.Fprintf(w, ". Add %d to the frontier: %s", len(added), strings.Join(added, ", "))
+		}
+		fmt.Fprintf(w, ". Frontier: %d, explored: %d\n", frontier, current.Explored)
+	}
+
+	for ev := range events {
+		switch ev.Kind {
+		case solve.EventExpand:
+			flush()
+			steps++
+			current, added, frontier = &ev, nil, ev.Frontier
+		case solve.EventEnqueue:
+			// The start is in the frontier before anything is expanded
+			if current == nil || steps > explainMaxSteps {
+				continue
+			}
+			node := explainNode(algo, ev)
+			if expanded[ev.Point] {
+				node += " (reopened)"
+			}
+			added, frontier = append(added, node), ev.Frontier
+		case solve.EventDone:
+			flush()
+			if steps > explainMaxSteps {
+				fmt.Fprintf(w, "  ... %d more expansions not narrated, -explain is meant for small mazes\n", steps-explainMaxSteps)
+			}
+
+			switch {
+			case errors.Is(ev.Err, solve.ErrNoSolution):
+				fmt.Fprintln(w, "The frontier is empty: every square reachable from the start has been explored, the goal can't be reached")
+			case ev.Err != nil:
+				fmt.Fprintf(w, "The search has stopped: %v\n", ev.Err)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// Get how algo picks the next node to expand
+func explainRule(algo maze.Algo, heuristic string) string {
+	switch algo {
+	case maze.BFS:
+		return "the frontier is a queue, the node added first is expanded first, so the squares are explored by " +
+			"their number of moves from the start"
+	case maze.DFS:
+		return "the frontier is a stack, the node added last is expanded first. Only one new neighbor is added at " +
+			"a time, and a square without any makes the search backtrack to add a neighbor of an earlier square"
+	case maze.DIJKSTRA:
+		return "the node with the lowest g, the cost of its path from the start, is expanded first"
+	case maze.PARALLEL_DIJKSTRA:
+		return "the nodes with the lowest g, the cost of their path from the start, are expanded first, all at " +
+			"once, then their neighbors are added to the frontier"
+	case maze.GBFS:
+		return fmt.Sprintf("the node with the lowest h, the %s estimate of its cost to the goal, is expanded first", heuristic)
+	case maze.ASTAR:
+		return fmt.Sprintf("the node with the lowest f = g + h is expanded first, g being the cost of its path from "+
+			"the start and h the %s estimate of its cost to the goal", heuristic)
+	}
+
+	return "custom algorithm"
+}
+
+// Get why algo expands a node that isn't the start
+func explainReason(algo maze.Algo) string {
+	switch algo {
+	case maze.BFS:
+		return "the oldest node of the frontier"
+	case maze.DFS:
+		return "the newest node of the frontier"
+	case maze.DIJKSTRA, maze.PARALLEL_DIJKSTRA:
+		return "the lowest g of the frontier"
+	case maze.GBFS:
+		return "the lowest h of the frontier"
+	case maze.ASTAR:
+		return "the lowest f of the frontier"
+	}
+
+	return "picked by the frontier"
+}
+
+// Describe the node of an event with the costs algo orders the frontier by
+func explainNode(algo maze.Algo, ev solve.SearchEvent) string {
+	p := explainPoint(ev.Point)
+	switch algo {
+	case maze.DIJKSTRA, maze.PARALLEL_DIJKSTRA:
+		return fmt.Sprintf("%s g=%d", p, ev.PathCost)
+	case maze.GBFS:
+		return fmt.Sprintf("%s h=%d", p, ev.Estimate)
+	case maze.ASTAR:
+		return fmt.Sprintf("%s f=%d (g=%d + h=%d)", p, ev.Cost, ev.PathCost, ev.Estimate)
+	}
+
+	return p
+}
+
+// Write a square like the solution does
+func explainPoint(p maze.Point) string {
+	return fmt.Sprintf("(%d, %d)", p.Row, p.Col)
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/mazepb"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// The gRPC service of the serve command, the same solves as the HTTP API with typed messages
+type grpcServer struct {
+	mazepb.UnimplementedMazeSolverServer
+	cfg ServeConfig
+}
+
+// Create the gRPC server of the serve command
+func NewGRPCServer(cfg ServeConfig) *grpc.Server {
+	if cfg.metrics == nil {
+		cfg.metrics = newServerMetrics()
+	}
+
+	if cfg.limiter == nil {
+		cfg.limiter = newRateLimiter()
+	}
+
+	s := &grpcServer{cfg: cfg}
+	srv := grpc.NewServer(grpc.MaxRecvMsgSize(int(cfg.MaxBytes)), grpc.UnaryInterceptor(s.guardUnary),
+		grpc.StreamInterceptor(s.guardStream))
+	mazepb.RegisterMazeSolverServer(srv, s)
+	return srv
+}
+
+// Get the API key in the metadata of a call, empty if it has none. It's sent like the headers of the HTTP API
+func grpcKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		return keys[0]
+	}
+
+	return ""
+}
+
+// Refuse the calls with an invalid or missing key, and the clients over their rate limit, like the HTTP API does.
+// Get the context of the call with its client, whose limits apply to the solve
+func (s *grpcServer) guard(ctx context.Context) (context.Context, error) {
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	client, err := s.cfg.clientOf(grpcKey(ctx), addr)
+	if err != nil {
+		s.cfg.metrics.failed("grpc", reasonUnauthorized)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if wait := s.cfg.rateLimit("grpc", client); wait > 0 {
+		return nil, status.Error(codes.ResourceExhausted, rateLimitMessage(client, wait))
+	}
+
+	return context.WithValue(ctx, clientContextKey{}, client), nil
+}
+
+// Guard the unary calls, see guard
+func (s *grpcServer) guardUnary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.guard(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// Guard the streaming calls, see guard
+func (s *grpcServer) guardStream(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.guard(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, clientStream{stream, ctx})
+}
+
+// A stream whose context holds the client of the call
+type clientStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s clientStream) Context() context.Context {
+	return s.ctx
+}
+
+// Load the maze of a request, within the limits of the client of ctx, and create its solver. The error is a gRPC status
+func (s *grpcServer) solver(ctx context.Context, req *mazepb.SolveRequest) (solve.Solver, maze.Algo, error) {
+	algo, topology, h, err := solveParams(req.GetAlgo(), req.GetMaze().GetMovement(), req.GetHeuristic())
+	if err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	m, err := s.cfg.loadMaze(ctx, req.GetMaze().GetText(), topology)
+	var tooMany maze.ErrTooLarge
+	if errors.As(err, &tooMany) {
+		return nil, "", status.Error(codes.ResourceExhausted, err.Error())
+	} else if err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	solver, err := solve.NewSolverForAlgo(algo, m, h, solve.WithoutTrace())
+	if err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return solver, algo, nil
+}
+
+// Get the reason label of the metrics of a status returned by solver
+func grpcReason(err error) string {
+	if status.Code(err) == codes.ResourceExhausted {
+		return reasonTooLarge
+	}
+
+	return reasonBadRequest
+}
+
+// Turn the error of a solve into a gRPC status, nil when the solve has ended, with or without a solution
+func (s *grpcServer) solveStatus(err error) error {
+	var invalid maze.ErrInvalidMaze
+	var cost maze.ErrUnsupportedCost
+	switch {
+	case err == nil, errors.Is(err, solve.ErrNoSolution):
+		return nil
+	case errors.As(err, &invalid), errors.As(err, &cost):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, fmt.Sprintf("solving took longer than %s", s.cfg.Timeout))
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// Get the message of a solution, err is the error of the solve that has returned it. The path has no squares when the
+// start is the goal, which is still solved
+func solutionMessage(algo maze.Algo, solver solve.Solver, result maze.Result, err error) *mazepb.Solution {
+	solution := &mazepb.Solution{
+		Algo:   string(algo),
+		Solved: err == nil,
+		Stats: &mazepb.Stats{
+			NodesExpanded:  int64(result.Expanded),
+			NodesGenerated: int64(result.Generated),
+			FrontierPeak:   int64(result.FrontierPeak),
+			PathLength:     int64(result.PathLength),
+			PathCost:       int64(result.PathCost),
+			SolveTimeNs:    result.SolveTime.Nanoseconds(),
+		},
+	}
+	if h := solve.HeuristicOf(solver); h != nil {
+		solution.Heuristic = h.Name()
+	}
+	for _, p := range result.Solution.Path {
+		solution.Path = append(solution.Path, &mazepb.Point{Row: int32(p.Row), Col: int32(p.Col)})
+	}
+	for _, action := range result.Solution.Actions {
+		solution.Actions = append(solution.Actions, string(action))
+	}
+
+	return solution
+}
+
+// Solve the maze of the request and answer its solution
+func (s *grpcServer) Solve(ctx context.Context, req *mazepb.SolveRequest) (*mazepb.Solution, error) {
+	solver, algo, err := s.solver(ctx, req)
+	if err != nil {
+		s.cfg.metrics.failed("grpc", grpcReason(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	result, err := solver.SolveContext(ctx)
+	s.cfg.metrics.solved("grpc", algo, result, err)
+	if err := s.solveStatus(err); err != nil {
+		return nil, err
+	}
+
+	LOGGER.Info("Maze solved over gRPC", "algo", algo, "second(s)", result.SolveTime.Seconds(),
+		"expanded", result.Expanded, "path_cost", result.PathCost)
+	return solutionMessage(algo, solver, result, err), nil
+}
+
+// The kinds of the events of the solvers in the messages
+var eventKinds = map[solve.EventKind]mazepb.EventKind{
+	solve.EventExpand:  mazepb.EventKind_EVENT_KIND_EXPAND,
+	solve.EventEnqueue: mazepb.EventKind_EVENT_KIND_ENQUEUE,
+	solve.EventDone:    mazepb.EventKind_EVENT_KIND_DONE,
+}
+
+// Solve the maze of the request and stream its events while solving, then the solution. The solve stops when the
+// client goes away
+func (s *grpcServer) SolveStream(req *mazepb.SolveRequest, stream grpc.ServerStreamingServer[mazepb.SolveEvent]) error {
+	solver, algo, err := s.solver(stream.Context(), req)
+	if err != nil {
+		s.cfg.metrics.failed("grpc", grpcReason(err))
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(stream.Context(), s.cfg.Timeout)
+	defer cancel()
+
+	type solveResult struct {
+		result maze.Result
+		err    error
+	}
+	events := solver.Events()
+	finished := make(chan solveResult, 1)
+	go func() {
+		result, err := solver.SolveContext(ctx)
+		finished <- solveResult{result, err}
+	}()
+
+	// The events are read until the channel is closed, even once the client is gone, so the solver never waits
+	for ev := range events {
+		if ev.Kind == solve.EventDone || ctx.Err() != nil {
+			continue
+		}
+
+		if err := stream.Send(&mazepb.SolveEvent{
+			Kind:     eventKinds[ev.Kind],
+			Point:    &mazepb.Point{Row: int32(ev.Point.Row), Col: int32(ev.Point.Col)},
+			Frontier: int32(ev.Frontier),
+			Explored: int32(ev.Explored),
+		}); err != nil {
+			cancel()
+		}
+	}
+
+	done := <-finished
+	s.cfg.metrics.solved("grpc", algo, done.result, done.err)
+	if err := s.solveStatus(done.err); err != nil {
+		return err
+	}
+
+	LOGGER.Info("Maze solved over gRPC", "algo", algo, "second(s)", done.result.SolveTime.Seconds(),
+		"expanded", done.result.Expanded, "path_cost", done.result.PathCost)
+	return stream.Send(&mazepb.SolveEvent{
+		Kind:     mazepb.EventKind_EVENT_KIND_DONE,
+		Explored: int32(done.result.Expanded),
+		Solution: solutionMessage(algo, solver, done.result, done.err),
+	})
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/mazepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Serve the gRPC API of cfg on a local port, and get a client of it
+func startGRPC(t *testing.T, cfg ServeConfig) mazepb.MazeSolverClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewGRPCServer(cfg)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return mazepb.NewMazeSolverClient(conn)
+}
+
+// Solve a small maze over client with the metadata of md, and get the status code of the unary and streaming calls
+func grpcCodes(t *testing.T, client mazepb.MazeSolverClient, md ...string) (codes.Code, codes.Code) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, md...)
+	req := &mazepb.SolveRequest{Maze: &mazepb.Maze{Text: "A #\n  B"}}
+
+	_, err := client.Solve(ctx, req)
+	unary := status.Code(err)
+
+	stream, err := client.SolveStream(ctx, req)
+	if err != nil {
+		return unary, status.Code(err)
+	}
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if errors.Is(err, io.EOF) {
+		return unary, codes.OK
+	}
+	return unary, status.Code(err)
+}
+
+// With -require-key, the calls without a key or with an unknown key are refused, over gRPC like over HTTP
+func TestGRPCRequireKey(t *testing.T) {
+	client := startGRPC(t, ServeConfig{
+		Timeout:    5 * time.Second,
+		MaxBytes:   1 << 20,
+		RequireKey: true,
+		keys:       map[string]apiKey{"secret": {Key: "secret", Name: "test"}},
+	})
+
+	tests := []struct {
+		name string
+		md   []string
+		want codes.Code
+	}{
+		{"no key", nil, codes.Unauthenticated},
+		{"unknown key", []string{"x-api-key", "wrong"}, codes.Unauthenticated},
+		{"bearer", []string{"authorization", "Bearer secret"}, codes.OK},
+		{"x-api-key", []string{"x-api-key", "secret"}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unary, stream := grpcCodes(t, client, tt.md...)
+			if unary != tt.want || stream != tt.want {
+				t.Errorf("Solve answers %s and SolveStream %s, expected %s", unary, stream, tt.want)
+			}
+		})
+	}
+}
+
+// The rate limit and the max_cells quota of a key apply to its gRPC calls
+func TestGRPCKeyLimits(t *testing.T) {
+	client := startGRPC(t, ServeConfig{
+		Timeout:  5 * time.Second,
+		MaxBytes: 1 << 20,
+		keys: map[string]apiKey{
+			"slow":  {Key: "slow", Name: "slow", Rate: 0.001, Burst: 1},
+			"small": {Key: "small", Name: "small", MaxCells: 4},
+		},
+	})
+
+	if unary, stream := grpcCodes(t, client, "x-api-key", "slow"); unary != codes.OK || stream != codes.ResourceExhausted {
+		t.Errorf("over the rate limit, Solve answers %s and SolveStream %s, expected OK then %s", unary, stream,
+			codes.ResourceExhausted)
+	}
+	if unary, stream := grpcCodes(t, client, "x-api-key", "small"); unary != codes.ResourceExhausted || stream != codes.ResourceExhausted {
+		t.Errorf("over max_cells, Solve answers %s and SolveStream %s, expected %s", unary, stream, codes.ResourceExhausted)
+	}
+}
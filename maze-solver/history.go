@@ -0,0 +1,376 @@
+package main
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The database/sql driver the history is opened with, set by history_sqlite.go when built with the sqlite tag
+var historyDriver string
+
+// Returned when the history is used by a binary built without a SQLite driver
+var errNoSQLite = errors.New("this binary is built without SQLite, rebuild it with -tags sqlite to use the history")
+
+// The tables of the history. Times are RFC 3339 text, durations nanoseconds, and the lists JSON arrays
+var historySchema = []string{
+	`CREATE TABLE IF NOT EXISTS runs (
+		id            INTEGER PRIMARY KEY,
+		recorded_at   TEXT    NOT NULL,
+		maze_hash     TEXT    NOT NULL,
+		maze          TEXT    NOT NULL,
+		height        INTEGER NOT NULL,
+		width         INTEGER NOT NULL,
+		algo          TEXT    NOT NULL,
+		heuristic     TEXT    NOT NULL,
+		movement      TEXT    NOT NULL,
+		options       TEXT    NOT NULL,
+		solved        INTEGER NOT NULL,
+		path_length   INTEGER NOT NULL,
+		path_cost     INTEGER NOT NULL,
+		expanded      INTEGER NOT NULL,
+		generated     INTEGER NOT NULL,
+		frontier_peak INTEGER NOT NULL,
+		solve_time_ns INTEGER NOT NULL,
+		artifacts     TEXT    NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS runs_maze_algo ON runs (maze_hash, algo)`,
+}
+
+// The solves recorded into a SQLite database with -history
+type History struct {
+	db *sql.DB
+}
+
+// One recorded solve
+type HistoryRun struct {
+	ID           int64         `json:"id"`
+	RecordedAt   time.Time     `json:"recorded_at"`
+	MazeHash     string        `json:"maze_hash"` // SHA-256 of the maze text, so a maze is recognized under any file name
+	Maze         string        `json:"maze"`      // The input file of the solve
+	Height       int           `json:"height"`
+	Width        int           `json:"width"`
+	Algo         maze.Algo     `json:"algo"`
+	Heuristic    string        `json:"heuristic,omitempty"`
+	Movement     string        `json:"movement"`
+	Options      string        `json:"options"` // JSON object of the other options the solve depends on
+	Solved       bool          `json:"solved"`
+	PathLength   int           `json:"path_length"`
+	PathCost     int           `json:"path_cost"`
+	Expanded     int           `json:"nodes_expanded"`
+	Generated    int           `json:"nodes_generated"`
+	FrontierPeak int           `json:"frontier_peak"`
+	SolveTime    time.Duration `json:"solve_time_ns"`
+	Artifacts    []string      `json:"artifacts"` // The output files written by the run
+}
+
+// The options of a run, besides the algorithm, heuristic and movement, that change what a solve finds
+type historyOptions struct {
+	Timeout  string `json:"timeout,omitempty"`
+	MaxNodes int    `json:"max_nodes,omitempty"`
+	Workers  int    `json:"workers,omitempty"`
+	Field    bool   `json:"field,omitempty"`
+}
+
+// Open the history database at path, creating it if needed
+func OpenHistory(path string) (*History, error) {
+	if historyDriver == "" {
+		return nil, errNoSQLite
+	}
+
+	db, err := sql.Open(historyDriver, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, stmt := range historySchema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create the history tables: %v", err)
+		}
+	}
+
+	return &History{db: db}, nil
+}
+
+// Close the database
+func (h *History) Close() error {
+	return h.db.Close()
+}
+
+// Record a run
+func (h *History) Record(run HistoryRun) error {
+	artifacts, err := json.Marshal(run.Artifacts)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.db.Exec(`INSERT INTO runs (recorded_at, maze_hash, maze, height, width, algo, heuristic, movement, options,
+		solved, path_length, path_cost, expanded, generated, frontier_peak, solve_time_ns, artifacts)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.RecordedAt.UTC().Format(time.RFC3339Nano), run.MazeHash, run.Maze, run.Height, run.Width, string(run.Algo),
+		run.Heuristic, run.Movement, run.Options, run.Solved, run.PathLength, run.PathCost, run.Expanded, run.Generated,
+		run.FrontierPeak, int64(run.SolveTime), string(artifacts))
+	return err
+}
+
+// Get the recorded runs, oldest first, of the maze with mazeHash and of algo. An empty filter matches every run
+func (h *History) Runs(mazeHash string, algo maze.Algo) ([]HistoryRun, error) {
+	rows, err := h.db.Query(`SELECT id, recorded_at, maze_hash, maze, height, width, algo, heuristic, movement, options,
+		solved, path_length, path_cost, expanded, generated, frontier_peak, solve_time_ns, artifacts
+		FROM runs WHERE (? = '' OR maze_hash = ?) AND (? = '' OR algo = ?) ORDER BY id`,
+		mazeHash, mazeHash, string(algo), string(algo))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []HistoryRun
+	for rows.Next() {
+		var run HistoryRun
+		var recordedAt, artifacts string
+		var solveTime int64
+		err := rows.Scan(&run.ID, &recordedAt, &run.MazeHash, &run.Maze, &run.Height, &run.Width, &run.Algo,
+			&run.Heuristic, &run.Movement, &run.Options, &run.Solved, &run.PathLength, &run.PathCost, &run.Expanded,
+			&run.Generated, &run.FrontierPeak, &solveTime, &artifacts)
+		if err != nil {
+			return nil, err
+		}
+		if run.RecordedAt, err = time.Parse(time.RFC3339Nano, recordedAt); err != nil {
+			return nil, fmt.Errorf("invalid time of run %d: %v", run.ID, err)
+		}
+		if err := json.Unmarshal([]byte(artifacts), &run.Artifacts); err != nil {
+			return nil, fmt.Errorf("invalid artifacts of run %d: %v", run.ID, err)
+		}
+		run.SolveTime = time.Duration(solveTime)
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// Get the hash a maze is recorded under
+func mazeHash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get the name of a built-in topology, empty for the others
+func movementName(t maze.Topology) string {
+	for _, name := range maze.TopologyNames() {
+		if builtin, _ := maze.TopologyByName(name); builtin == t {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// Get the output files of cfg written for a solve, the ones that don't exist (not asked for or failed) are left out
+func runArtifacts(cfg Config, algo maze.Algo) []string {
+	var planned []string
+	if cfg.Text == nil && !cfg.NoTrace {
+		for ext, asked := range map[string]bool{"png": cfg.PNG, "svg": cfg.SVG, "gif": cfg.GIF} {
+			if asked {
+				planned = append(planned, cfg.ResultFilename(string(algo), ext))
+			}
+		}
+	}
+	if cfg.DOT {
+		planned = append(planned, cfg.ResultFilename(string(algo), "dot"))
+	}
+	if cfg.Replay {
+		planned = append(planned, cfg.ResultFilename(string(algo), "replay"))
+	}
+	planned = append(planned, cfg.JSON, cfg.Report)
+
+	artifacts := []string{}
+	for _, path := range planned {
+		if _, err := os.Stat(path); path != "" && err == nil {
+			artifacts = append(artifacts, path)
+		}
+	}
+	slices.Sort(artifacts)
+
+	return artifacts
+}
+
+// Record the solves of a maze into the history database of cfg. data is the maze text
+func RecordHistory(cfg Config, data string, mazes []*maze.Solved) error {
+	history, err := OpenHistory(cfg.History)
+	if err != nil {
+		return err
+	}
+	defer history.Close()
+
+	options := historyOptions{MaxNodes: cfg.MaxNodes, Workers: cfg.Workers, Field: cfg.Field}
+	if cfg.Timeout > 0 {
+		options.Timeout = cfg.Timeout.String()
+	}
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	hash, now := mazeHash(data), time.Now()
+	for _, m := range mazes {
+		err := history.Record(HistoryRun{
+			RecordedAt:   now,
+			MazeHash:     hash,
+			Maze:         cfg.Input,
+			Height:       m.Height,
+			Width:        m.Width,
+			Algo:         m.SearchType,
+			Heuristic:    m.Heuristic,
+			Movement:     movementName(m.Topology),
+			Options:      string(encoded),
+			Solved:       m.Found(),
+			PathLength:   m.PathLength,
+			PathCost:     m.PathCost,
+			Expanded:     m.Expanded,
+			Generated:    m.Generated,
+			FrontierPeak: m.FrontierPeak,
+			SolveTime:    m.SolveTime,
+			Artifacts:    runArtifacts(cfg, m.SearchType),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	LOGGER.Info("Record history successfully", "path", cfg.History, "runs", len(mazes))
+	return nil
+}
+
+// Two runs are comparable when they solve the same maze with the same algorithm and options
+func historyKey(run HistoryRun) string {
+	return strings.Join([]string{run.MazeHash, string(run.Algo), run.Heuristic, run.Movement, run.Options}, "\x00")
+}
+
+// Print runs as a table, each with the change of its path cost, expanded nodes and solve time since the previous
+// comparable run. runs are the oldest first, and only the last limit ones are printed (all of them when limit <= 0)
+func PrintHistory(w io.Writer, runs []HistoryRun, limit int) {
+	previous := map[string]HistoryRun{}
+	changes := make([]string, len(runs))
+	for i, run := range runs {
+		key := historyKey(run)
+		if prev, ok := previous[key]; ok {
+			changes[i] = historyChange(prev, run)
+		} else {
+			changes[i] = "first run"
+		}
+		previous[key] = run
+	}
+	if limit > 0 && len(runs) > limit {
+		runs, changes = runs[len(runs)-limit:], changes[len(changes)-limit:]
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tRecorded\tMaze\tAlgorithm\tSolved\tPath cost\tExpanded\tTime\tSince the previous run\t")
+	for i, run := range runs {
+		algo := string(run.Algo)
+		if run.Heuristic != "" {
+			algo += " (" + run.Heuristic + ")"
+		}
+		if run.Movement != "four" {
+			algo += ", " + run.Movement
+		}
+		solved := "no"
+		if run.Solved {
+			solved = "yes"
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t\n", run.ID, run.RecordedAt.Local().Format(time.DateTime),
+			run.Maze, algo, solved, run.PathCost, run.Expanded, run.SolveTime.Round(time.Microsecond), changes[i])
+	}
+	tw.Flush()
+}
+
+// Describe what has changed from the run prev to run
+func historyChange(prev, run HistoryRun) string {
+	var changes []string
+	if prev.Solved != run.Solved {
+		changes = append(changes, fmt.Sprintf("solved %t -> %t", prev.Solved, run.Solved))
+	}
+	if prev.PathCost != run.PathCost {
+		changes = append(changes, fmt.Sprintf("cost %+d", run.PathCost-prev.PathCost))
+	}
+	if prev.Expanded != run.Expanded {
+		changes = append(changes, fmt.Sprintf("expanded %+d", run.Expanded-prev.Expanded))
+	}
+	if prev.SolveTime > 0 {
+		changes = append(changes, fmt.Sprintf("time %+.0f%%", 100*(float64(run.SolveTime)/float64(prev.SolveTime)-1)))
+	}
+	if len(changes) == 0 {
+		return "same"
+	}
+
+	return strings.Join(changes, ", ")
+}
+
+// Run the history command
+func HistoryCommand(args []string) error {
+	var db, input, algo string
+	var limit int
+	var asJSON bool
+	fs := newFlagSet("history", "", "List the solves recorded with -history, the latest last. Each run is compared with the\n"+
+		"previous run of the same maze (recognized by its content, not its file name), algorithm and options: the change\n"+
+		"of its path cost, expanded nodes and solve time is shown, so a regression stands out.")
+	fs.StringVar(&db, "db", "", "The history database, the -history of solve and compare")
+	fs.StringVar(&input, "maze", "", "Only list the runs of the maze of this file, - reads it from stdin")
+	fs.StringVar(&algo, "algo", "", "Only list the runs of this algorithm")
+	fs.IntVar(&limit, "limit", 20, "List the last this many runs, 0 means all of them")
+	fs.BoolVar(&asJSON, "json", false, "Print the runs as JSON instead of a table")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if db == "" {
+		return errors.New("no -db given")
+	}
+
+	hash := ""
+	if input != "" {
+		data, err := ReadFile(input)
+		if err != nil {
+			return fmt.Errorf("failed to read maze: %v", err)
+		}
+		hash = mazeHash(data)
+	}
+
+	history, err := OpenHistory(db)
+	if err != nil {
+		return err
+	}
+	defer history.Close()
+
+	runs, err := history.Runs(hash, maze.Algo(algo))
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if limit > 0 && len(runs) > limit {
+			runs = runs[len(runs)-limit:]
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(runs)
+	}
+
+	if len(runs) == 0 {
+		fmt.Println("No run recorded")
+		return nil
+	}
+	PrintHistory(os.Stdout, runs, limit)
+	return nil
+}
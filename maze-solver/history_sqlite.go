@@ -0,0 +1,11 @@
+//go:build sqlite
+
+package main
+
+import (
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	historyDriver = "sqlite"
+}
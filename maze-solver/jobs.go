@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// The state of a job
+type jobState string
+
+const (
+	jobQueued  jobState = "queued"
+	jobRunning jobState = "running"
+	jobDone    jobState = "done"   // Solved, with or without a solution
+	jobFailed  jobState = "failed" // The solve has returned an error or has been stopped
+)
+
+// How long a finished job can still be asked for
+const jobRetention = time.Hour
+
+// A solve run in the background by the workers of a jobQueue
+type job struct {
+	id       string
+	algo     maze.Algo
+	solver   solve.Solver
+	m        *maze.Maze
+	created  time.Time
+	open     int          // Open squares of the maze, the most nodes the solve can expand
+	formats  []string     // The artifacts to store once solved, from solveFormats
+	expanded atomic.Int64 // Updated while solving
+	frontier atomic.Int64
+
+	// Set by the worker, under the lock of the queue
+	state    jobState
+	started  time.Time
+	finished time.Time
+	result   json.RawMessage   // The JSON export of the solve once done
+	urls     map[string]string // The URL of every stored artifact, by format
+	err      string
+}
+
+// The answer of GET /jobs/{id}
+type jobStatus struct {
+	ID         string            `json:"id"`
+	State      jobState          `json:"state"`
+	Algo       maze.Algo         `json:"algo"`
+	CreatedAt  time.Time         `json:"created_at"`
+	StartedAt  *time.Time        `json:"started_at,omitempty"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+	Expanded   int64             `json:"expanded"` // Nodes expanded so far
+	Frontier   int64             `json:"frontier"` // Nodes in the frontier after the last expansion
+	Open       int               `json:"open"`     // Open squares of the maze, the most nodes the solve can expand
+	Progress   float64           `json:"progress"` // Expanded nodes over the open squares, an upper bound of the work left
+	Error      string            `json:"error,omitempty"`
+	Result     json.RawMessage   `json:"result,omitempty"`    // The JSON export of the solve, like POST /solve answers
+	Artifacts  map[string]string `json:"artifacts,omitempty"` // The URLs of the artifacts asked for, by format
+}
+
+// Solves waiting for a worker and the jobs kept to be asked for. At most JobWorkers jobs run at the same time, and at
+// most JobQueue jobs wait
+type jobQueue struct {
+	ctx     context.Context
+	cfg     ServeConfig
+	pending chan *job
+
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+// Returned by submit when capacity jobs are already waiting
+var errQueueFull = errors.New("too many jobs are waiting, try again later")
+
+// Create the job queue of the server and start its workers, which stop when ctx is done
+func newJobQueue(ctx context.Context, cfg ServeConfig) *jobQueue {
+	q := &jobQueue{
+		ctx:     ctx,
+		cfg:     cfg,
+		pending: make(chan *job, cfg.JobQueue),
+		jobs:    map[string]*job{},
+	}
+	for range max(cfg.JobWorkers, 1) {
+		go q.work()
+	}
+
+	return q
+}
+
+// Queue a solve of m with solver, storing the artifacts of formats once solved, and get its job
+func (q *jobQueue) submit(m *maze.Maze, algo maze.Algo, solver solve.Solver, formats []string) (*job, error) {
+	id := make([]byte, 8)
+	rand.Read(id)
+	j := &job{id: hex.EncodeToString(id), algo: algo, solver: solver, m: m, created: time.Now(), state: jobQueued,
+		open: m.GetEmptySquares(), formats: formats}
+	solver.OnExpand(func(ev solve.ExpandEvent) {
+		j.expanded.Store(int64(ev.Explored))
+		j.frontier.Store(int64(ev.Frontier))
+	})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	select {
+	case q.pending <- j:
+		q.jobs[j.id] = j
+		return j, nil
+	default:
+		return nil, errQueueFull
+	}
+}
+
+// Forget the jobs finished for longer than jobRetention. The lock must be held
+func (q *jobQueue) prune() {
+	for id, j := range q.jobs {
+		if !j.finished.IsZero() && time.Since(j.finished) > jobRetention {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// Run the pending jobs one after the other until the context of the queue is done
+func (q *jobQueue) work() {
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case j := <-q.pending:
+			q.run(j)
+		}
+	}
+}
+
+// Solve a job and keep its result
+func (q *jobQueue) run(j *job) {
+	q.mu.Lock()
+	j.state, j.started = jobRunning, time.Now()
+	q.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(q.ctx, q.cfg.JobTimeout)
+	defer cancel()
+	result, err := j.solver.SolveContext(ctx)
+	q.cfg.metrics.solved("jobs", j.algo, result, err)
+
+	var export []byte
+	var urls map[string]string
+	if err == nil || errors.Is(err, solve.ErrNoSolution) {
+		solved := &maze.Solved{Maze: j.m, SearchType: j.algo, Result: result}
+		if h := solve.HeuristicOf(j.solver); h != nil {
+			solved.Heuristic = h.Name()
+		}
+
+		var buf *bytes.Buffer
+		if buf, err = q.cfg.renderFormat("job "+j.id, solved, "json"); err == nil {
+			export = buf.Bytes()
+			urls, err = q.store(j, solved)
+		}
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("solving took longer than %s", q.cfg.JobTimeout)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	j.finished = time.Now()
+	j.solver, j.m = nil, nil
+	if err != nil {
+		j.state, j.err = jobFailed, err.Error()
+		return
+	}
+	j.state, j.result, j.urls = jobDone, export, urls
+	LOGGER.Info("Job solved", "id", j.id, "algo", j.algo, "second(s)", result.SolveTime.Seconds(),
+		"expanded", result.Expanded, "path_cost", result.PathCost)
+}
+
+// Render the artifacts of a solved job and write them into the storage of the server, and get their URLs by format
+func (q *jobQueue) store(j *job, solved *maze.Solved) (map[string]string, error) {
+	urls := map[string]string{}
+	for _, format := range j.formats {
+		if format == "gif" {
+			if err := render.CheckGIFBudget(solved, render.DefaultRenderOptions()); err != nil {
+				return nil, err
+			}
+		}
+
+		buf, err := q.cfg.renderFormat("job "+j.id, solved, format)
+		if err != nil {
+			return nil, err
+		}
+
+		name := fmt.Sprintf("jobs/%s/maze_%s.%s", j.id, j.algo, format)
+		if urls[format], err = q.cfg.artifacts.Put(q.ctx, name, formatTypes[format], buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to store the %s: %v", format, err)
+		}
+	}
+
+	return urls, nil
+}
+
+// Get the status of the job id, false if there is no such job
+func (q *jobQueue) status(id string) (jobStatus, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	j, ok := q.jobs[id]
+	if !ok {
+		return jobStatus{}, false
+	}
+
+	status := jobStatus{
+		ID:        j.id,
+		State:     j.state,
+		Algo:      j.algo,
+		CreatedAt: j.created,
+		Expanded:  j.expanded.Load(),
+		Frontier:  j.frontier.Load(),
+		Open:      j.open,
+		Error:     j.err,
+		Result:    j.result,
+		Artifacts: j.urls,
+	}
+	if !j.started.IsZero() {
+		status.StartedAt = &j.started
+	}
+	if !j.finished.IsZero() {
+		status.FinishedAt = &j.finished
+	}
+	if j.state == jobDone {
+		status.Progress = 1
+	} else if j.open > 0 {
+		status.Progress = min(1, float64(status.Expanded)/float64(j.open))
+	}
+
+	return status, true
+}
+
+// Write a value as the JSON answer with code
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// Queue a solve of the maze of the request body, which takes the same parameters as POST /solve, and answer the id of
+// its job right away. The artifacts query parameter lists the formats to write into the storage once solved
+func (cfg ServeConfig) handleSubmitJob(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	algo, topology, h, err := solveParams(query.Get("algo"), query.Get("movement"), query.Get("heuristic"))
+	if err != nil {
+		cfg.metrics.failed("jobs", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, cfg.MaxBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		cfg.metrics.failed("jobs", reasonTooLarge)
+		http.Error(w, fmt.Sprintf("maze is larger than %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		cfg.metrics.failed("jobs", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m, err := cfg.loadMaze(r.Context(), string(body), topology)
+	var tooMany maze.ErrTooLarge
+	if errors.As(err, &tooMany) {
+		cfg.metrics.failed("jobs", reasonTooLarge)
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		cfg.metrics.failed("jobs", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var formats []string
+	if list := query.Get("artifacts"); list != "" {
+		for format := range strings.SplitSeq(list, ",") {
+			if format = strings.TrimSpace(format); !slices.Contains(solveFormats, format) {
+				cfg.metrics.failed("jobs", reasonBadRequest)
+				http.Error(w, fmt.Sprintf("unknown artifact %q, supported: %s", format, strings.Join(solveFormats, ", ")),
+					http.StatusBadRequest)
+				return
+			} else if !slices.Contains(formats, format) {
+				formats = append(formats, format)
+			}
+		}
+	}
+	if len(formats) > 0 && cfg.artifacts == nil {
+		cfg.metrics.failed("jobs", reasonBadRequest)
+		http.Error(w, "the server has no -storage to write artifacts into", http.StatusBadRequest)
+		return
+	}
+
+	// Checked now rather than by the solver, so an invalid maze is refused instead of becoming a failed job
+	if err := m.Validate(); err != nil {
+		cfg.metrics.failed("jobs", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Only the GIF animates the trace
+	var opts []solve.Option
+	if !slices.Contains(formats, "gif") {
+		opts = append(opts, solve.WithoutTrace())
+	}
+	solver, err := solve.NewSolverForAlgo(algo, m, h, opts...)
+	if err != nil {
+		cfg.metrics.failed("jobs", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	j, err := cfg.jobs.submit(m, algo, solver, formats)
+	if err != nil {
+		cfg.metrics.failed("jobs", reasonBusy)
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	status, _ := cfg.jobs.status(j.id)
+	w.Header().Set("Location", "/jobs/"+j.id)
+	writeJSON(w, http.StatusAccepted, status)
+}
+
+// Answer the status of a job, with the JSON export of the solve once it's done
+func (cfg ServeConfig) handleJob(w http.ResponseWriter, r *http.Request) {
+	status, ok := cfg.jobs.status(r.PathValue("id"))
+	if !ok {
+		http.Error(w, "no such job, finished jobs are kept for "+jobRetention.String(), http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
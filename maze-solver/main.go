@@ -1,185 +1,1005 @@
 package main
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"maze-solver/src"
+	"io"
+	"log/slog"
 	"os"
-	"sync"
+	"slices"
+	"strings"
+	"text/tabwriter"
 	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+var (
+	// Where the logs and the solution dumps are written. Commands writing a result to stdout move it to stderr
+	LOG_OUTPUT = &switchWriter{Writer: os.Stdout}
+
+	// Logger, replaced by the one of the -log-format and -log-level flags before running a command
+	LOGGER = slog.New(slog.NewTextHandler(LOG_OUTPUT, nil))
+
+	// How much is printed, set by the global flags: -1 with -q, 0 by default, 1 with -v and 2 with -vv
+	VERBOSITY = 0
+
+	// When the global -timeout is over, zero means no limit. The solves still running are stopped, and the outputs
+	// are written from what they have found, except the GIF which takes too long
+	DEADLINE time.Time
 )
 
-func Solve(solver src.Solver, maze *src.Maze) {
-	now := time.Now()
-	solver.Solve()
-	elapsed := time.Since(now)
+// A writer whose destination can be changed after it's given to a logger
+type switchWriter struct {
+	io.Writer
+}
+
+// Create a logger writing to w. format is text or json, level is debug, info, warn or error
+func NewLogger(w io.Writer, format, level string) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q, supported: debug, info, warn, error", level)
+	}
+
+	// The logs have no time in deterministic mode, which is only known once the flags of the command are parsed
+	opts := &slog.HandlerOptions{Level: l, ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && a.Key == slog.TimeKey && maze.Deterministic {
+			return slog.Attr{}
+		}
+		return a
+	}}
+	switch strings.ToLower(format) {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	}
+
+	return nil, fmt.Errorf("unknown log format %q, supported: text, json", format)
+}
+
+func Solve(ctx context.Context, cfg Config, solver solve.Solver, m *maze.Maze, algo maze.Algo) *maze.Solved {
+	result, err := solver.SolveContext(ctx)
+	solved := &maze.Solved{Maze: m, SearchType: algo, Result: result}
+	if h := solve.HeuristicOf(solver); h != nil {
+		solved.Heuristic = h.Name()
+	}
+
+	LogSolved(solved, err, !cfg.NoTrace)
+	return solved
+}
+
+// Log the statistics of a solved maze, with a warning if the solving has failed, and print its solution when withSolution
+// is set or a line with the answer otherwise. In quiet mode, only the line with the answer is printed
+func LogSolved(solved *maze.Solved, err error, withSolution bool) {
+	if VERBOSITY >= 0 {
+		logStats(slog.LevelInfo, solved, err)
+	}
+
+	if withSolution && VERBOSITY >= 0 {
+		fmt.Fprintln(LOG_OUTPUT, "Solution: ")
+		fmt.Fprintln(LOG_OUTPUT, solutionText(solved, err))
+	} else if err != nil {
+		fmt.Fprintf(LOG_OUTPUT, "%s: no solution: %v\n", solved.SearchType, err)
+	} else {
+		fmt.Fprintf(LOG_OUTPUT, "%s: path length %d, path cost %d\n", solved.SearchType, solved.PathLength, solved.PathCost)
+	}
+}
+
+// Describe the solution of a solve which has ended with err: its moves, or why it has none
+func solutionText(solved *maze.Solved, err error) string {
+	switch {
+	case solved.Unreachable:
+		return "none, the goal can't be reached from the start"
+	case err != nil:
+		return fmt.Sprintf("none, the search has stopped: %v", err)
+	case len(solved.Solution.Path) == 0 && solved.Found():
+		return "the start is the goal, no moves required"
+	}
+
+	return solved.Solution.String()
+}
+
+// Log the mean and standard deviation of the solve times of repeated runs, with a warning if they haven't all found
+// the same path
+func LogRuns(algo maze.Algo, runs solve.RunStats) {
+	LOGGER.Info("Runs complete", "algo", algo, "runs", len(runs.Times), "mean_second(s)", runs.Mean().Seconds(),
+		"stddev_second(s)", runs.StdDev().Seconds(), "same_path", runs.SamePath)
+	if !runs.SamePath {
+		LOGGER.Warn("The runs haven't all found the same path", "algo", algo)
+	}
+}
+
+// Log the statistics of a solved maze at level, with a warning if the solving has failed
+func logStats(level slog.Level, solved *maze.Solved, err error) {
+	algo := solved.SearchType
+	var limit solve.ErrLimitExceeded
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || errors.As(err, &limit) {
+		LOGGER.Warn("Maze solving stopped before finishing, the statistics below are partial", "algo", algo, "error", err)
+	} else if errors.Is(err, solve.ErrNoSolution) {
+		LOGGER.Warn("The goal can't be reached from the start, every reachable square has been explored", "algo", algo,
+			"explored", len(solved.Explored))
+	} else if err != nil {
+		LOGGER.Warn("Maze solving failed", "algo", algo, "error", err)
+	}
+
+	stats := solved.Stats
+	args := []any{"algo", algo, "second(s)", stats.SolveTime.Seconds(), "expanded", stats.Expanded,
+		"generated", stats.Generated, "frontier_peak", stats.FrontierPeak, "path_length", stats.PathLength,
+		"path_cost", stats.PathCost}
+	// The allocations are only measured with -allocs
+	if stats.Allocs > 0 {
+		args = append(args, "bytes_alloc", stats.BytesAlloc, "allocs", stats.Allocs)
+	}
+	LOGGER.Log(context.Background(), level, "Maze solving complete", args...)
+}
+
+// Create solver based on algo, with the heuristic and options of the run
+func NewSolver(cfg Config, m *maze.Maze, algo maze.Algo) (solve.Solver, error) {
+	solver, err := solve.NewSolverForAlgo(algo, m, cfg.Heuristic, cfg.SolverOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	if VERBOSITY >= 2 {
+		solver.OnExpand(func(ev solve.ExpandEvent) { LogExpand(algo, ev) })
+	}
+	return solver, nil
+}
+
+// Log an expanded node as a debug event, shown with -vv
+func LogExpand(algo maze.Algo, ev solve.ExpandEvent) {
+	p := ev.Node.Square.Coordinate
+	LOGGER.Debug("Expanded", "algo", algo, "row", p.Row, "col", p.Col, "cost", ev.Node.Cost,
+		"frontier", ev.Frontier, "explored", ev.Explored)
+}
+
+// Solve the maze while animating it in the terminal. The keys +, - and space change the speed and pause the solving
+func SolveLive(ctx context.Context, cfg Config, solver solve.Solver, m *maze.Maze, algo maze.Algo) *maze.Solved {
+	style := render.ASCIIStyle
+	if cfg.Text != nil {
+		style = *cfg.Text
+	}
+
+	view := render.NewLiveView(os.Stdout, style, cfg.Live)
+	solver.OnStep(view.Hook(m, algo))
+
+	restore, err := rawMode()
+	if err != nil {
+		LOGGER.Warn("Failed to read single key presses, press Enter after each key", "error", err)
+		restore = func() {}
+	}
+	defer restore()
+
+	// Keyboard controls
+	go func() {
+		key := make([]byte, 1)
+		for {
+			if _, err := os.Stdin.Read(key); err != nil {
+				return
+			}
+
+			switch key[0] {
+			case '+', '=':
+				view.Faster()
+			case '-', '_':
+				view.Slower()
+			case ' ', 'p':
+				view.TogglePause()
+			}
+		}
+	}()
 
-	src.LOGGER.Info("Maze solving complete", "algo", maze.SearchType, "second(s)", elapsed.Seconds())
-	src.LOGGER.Info("Path length", "algo", maze.SearchType, "val", len(maze.Solution.Path))
-	explored := len(maze.Explored)
-	coverage := float32(explored) / float32(maze.GetEmptySquares())
-	src.LOGGER.Info("Total node explored", "algo", maze.SearchType, "nodes", explored, "coverage", fmt.Sprintf("%.2f%%", coverage))
-	fmt.Println("Solution: ")
-	fmt.Println(maze.Solution)
+	solved := Solve(ctx, cfg, solver, m, algo)
+	view.Finish(solved)
+	return solved
 }
 
-func SolveWithAlgo(maze *src.Maze) {
-	// Create solver based on algo
-	var solver src.Solver
-	switch maze.SearchType {
-	case src.DFS:
-		solver = src.NewDFSSolver(maze)
-	case src.BFS:
-		solver = src.NewBFSSolver(maze)
-	case src.DIJKSTRA:
-		solver = src.NewDijkstraSolver(maze)
-	case src.GBFS:
-		solver = src.NewGBFSSolver(maze)
-	case src.ASTAR:
-		solver = src.NewAStarSolver(maze)
+// Write the DOT, replay, image and GIF outputs of one algorithm of SolveAllAlgo
+func WriteSolved(cfg Config, solved *maze.Solved) {
+	searchType := solved.SearchType
+	if cfg.DOT {
+		if err := WriteDOT(cfg, solved); err != nil {
+			LOGGER.Error("Failed to create search tree DOT", "algo", searchType, "error", err)
+		}
+	}
+
+	if cfg.Replay {
+		if err := WriteReplay(cfg, solved); err != nil {
+			LOGGER.Error("Failed to create replay", "algo", searchType, "error", err)
+		}
 	}
 
-	// Solve
-	Solve(solver, maze)
+	// Text is printed once every algorithm is done, so the mazes don't interleave
+	if cfg.Text != nil {
+		return
+	}
+
+	if err := Output(cfg, solved); err != nil {
+		LOGGER.Error("Failed to write image result to file system", "algo", searchType, "error", err)
+	}
 }
 
-func Output(input string, searchType src.Algo, maze *src.Maze) error {
-	src.LOGGER.Info("Start creating GIF result. This can take time depend on how large the maze")
+// Options of a CLI run
+type Config struct {
+	Input     string               // The maze input file
+	Strict    bool                 // Refuse a maze with rows of different lengths instead of padding them with walls
+	MaxCells  int                  // Refuse a maze with more squares than this, 0 means no limit
+	Render    render.RenderOptions // Options for image and GIF output
+	Sheet     bool                 // Create a comparison sheet when solving with all algorithms
+	Report    string               // Path of the HTML report, empty means no report
+	JSON      string               // Path of the JSON export, empty means no export
+	CSV       string               // Path of the CSV file to append metrics to, empty means no export
+	History   string               // Path of the SQLite database the solves are recorded into, empty means no history
+	DOT       bool                 // Export the search tree of each algorithm as GraphViz DOT
+	Replay    bool                 // Save the solver trace of each algorithm as a replay file
+	Text      *render.TextStyle    // Print the solved maze as text instead of writing image and GIF, nil means image
+	PNG       bool                 // Write the solution image, unless the maze is printed as text
+	GIF       bool                 // Write the solving animation, unless the maze is printed as text
+	SVG       bool                 // Write the solution image as SVG, unless the maze is printed as text
+	Timeout   time.Duration        // Stop each solve after this long, 0 means no limit
+	Live      time.Duration        // Animate the solving in the terminal while it happens, waiting this long per step. 0 means off
+	Explain   bool                 // Narrate the solve step by step: which node is expanded, why, and what is added to the frontier
+	OutDir    string               // Directory of the generated images, GIFs and DOT files
+	Force     bool                 // Overwrite existing output files
+	Name      string               // Template of the output file names
+	Start     time.Time            // When the run started, used for {timestamp} in the name template
+	Verify    bool                 // Check every solution after solving
+	Optimal   bool                 // Check that every path costs as little as the one of BFS or Dijkstra
+	MaxNodes  int                  // Stop each solve after expanding this many nodes, 0 means no limit
+	Workers   int                  // Goroutines of the parallel Dijkstra, 0 means one per CPU
+	Parallel  int                  // Algorithms solving at the same time when solving with all of them, 0 means all
+	Allocs    bool                 // Measure the heap allocations of every solve, counted for the whole process
+	Heuristic solve.Heuristic      // Heuristic of GBFS and A*, nil means the default of each algorithm
+	Field     bool                 // Use the distance field of the maze as the heuristic, computed once the maze is loaded
+	Topology  maze.Topology        // How the solvers move in the maze
+	Snapshot  string               // Path to save the state of an unfinished solve to, empty means no snapshot
+	Resume    string               // Path of the snapshot to carry on the solve from, empty means a new solve
+	Stdout    string               // The only result written to stdout: json, ascii or png. Empty means the usual outputs
+	NoTrace   bool                 // Record no solver trace and write no image, GIF or text render, only the numbers
+	Runs      int                  // Solve this many times to measure the mean solve time, 0 or 1 means once
+	DryRun    bool                 // Only print what would be written, without solving or rendering
+}
 
-	// Create the result image
-	img, err := src.CreateSolutionImage(maze)
+// Get the context of a single solve, which is cancelled after the timeout if set
+func (cfg Config) SolveContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if cfg.Timeout > 0 {
+		return context.WithTimeout(ctx, cfg.Timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// Get the options of the solvers
+func (cfg Config) SolverOptions() []solve.Option {
+	opts := []solve.Option{solve.WithMaxNodes(cfg.MaxNodes), solve.WithWorkers(cfg.Workers)}
+	if !cfg.Animated() {
+		opts = append(opts, solve.WithoutTrace())
+	}
+	if cfg.Allocs {
+		opts = append(opts, solve.WithAllocStats())
+	}
+
+	return opts
+}
+
+// Compute the distance field of m when it's the heuristic of the run, so it's computed once for every solver of the run
+func (cfg *Config) PrecomputeHeuristic(m *maze.Maze) error {
+	if !cfg.Field {
+		return nil
+	}
+
+	start := time.Now()
+	field, err := solve.NewDistanceField(m)
 	if err != nil {
 		return err
 	}
 
-	output := src.CreateResultFilename(".", input, string(searchType), "png")
-	if err = os.WriteFile(output, img.Bytes(), 0644); err != nil {
+	cfg.Heuristic = field
+	LOGGER.Info("Distance field precomputed", "second(s)", time.Since(start).Seconds())
+	return nil
+}
+
+// Whether the run animates the solving (GIF, replay, HTML report or live view), which needs the steps of the solvers
+func (cfg Config) Animated() bool {
+	return (cfg.Text == nil && cfg.GIF) || cfg.Replay || cfg.Report != "" || cfg.Live > 0
+}
+
+// Check the solution of the solved maze if enabled, a wrong solution is logged as an error
+func (cfg Config) VerifySolution(m *maze.Solved) {
+	// Nothing to check when the goal wasn't reached
+	if !cfg.Verify || !m.Found() {
+		return
+	}
+
+	if err := maze.VerifySolution(m.Maze, m.Solution); err != nil {
+		LOGGER.Error("Solution verification failed", "algo", m.SearchType, "error", err)
+		return
+	}
+
+	LOGGER.Info("Solution verified", "algo", m.SearchType)
+}
+
+// Compare the path cost of every solved maze with the optimal cost of the maze if enabled, and report the algorithms
+// whose path costs more, with how much more. The solves stopped before finishing are not checked
+func (cfg Config) CheckOptimal(ctx context.Context, m *maze.Maze, mazes []*maze.Solved) {
+	if !cfg.Optimal {
+		return
+	}
+
+	optimal, reference, err := solve.OptimalCost(ctx, m)
+	if err != nil && !errors.Is(err, solve.ErrNoSolution) {
+		LOGGER.Error("Failed to find the optimal path cost", "algo", reference, "error", err)
+		return
+	}
+	reachable := err == nil
+
+	for _, solved := range mazes {
+		algo := solved.SearchType
+		found := solved.Found()
+		switch {
+		case !found && !solved.Unreachable:
+			LOGGER.Warn("Optimality not checked, the solve has stopped before finishing", "algo", algo)
+		case !found && reachable:
+			LOGGER.Error("No path found, but the goal can be reached", "algo", algo, "optimal_cost", optimal, "reference", reference)
+		case !found:
+			LOGGER.Info("Path is optimal, the goal can't be reached", "algo", algo, "reference", reference)
+		case !reachable || solved.PathCost < optimal:
+			LOGGER.Error("Path is cheaper than the optimal one, the reference is wrong", "algo", algo, "path_cost", solved.PathCost,
+				"reference", reference)
+		case solved.PathCost > optimal:
+			LOGGER.Warn("Path is not optimal", "algo", algo, "path_cost", solved.PathCost, "optimal_cost", optimal,
+				"delta", solved.PathCost-optimal, "reference", reference)
+		default:
+			LOGGER.Info("Path is optimal", "algo", algo, "path_cost", solved.PathCost, "reference", reference)
+		}
+	}
+}
+
+// Load the snapshot of cfg.Resume into solver, so its next solve carries on from it
+func ResumeSolver(cfg Config, solver solve.Solver) error {
+	data, err := ReadFile(cfg.Resume)
+	if err != nil {
 		return err
 	}
 
-	// Create the GIF file
-	buf, err := src.CreateGIF(maze)
+	return solver.Resume([]byte(data))
+}
+
+// Save the state of the last solve of solver into cfg.Snapshot if it has stopped before finishing
+func WriteSnapshot(cfg Config, solver solve.Solver) error {
+	data, err := solver.Snapshot()
+	if errors.Is(err, solve.ErrNothingToSnapshot) {
+		LOGGER.Info("Maze solving has finished, no snapshot to save")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err = WriteResult(cfg.Snapshot, data, cfg.Force); err != nil {
+		return err
+	}
+
+	LOGGER.Info("Snapshot saved, carry on the solve with -resume", "path", cfg.Snapshot)
+	return nil
+}
+
+// Get the path of an output file of the run
+func (cfg Config) ResultFilename(algo, ext string) string {
+	return FormatResultFilename(cfg.Name, cfg.OutDir, cfg.Input, algo, ext, cfg.Start)
+}
+
+// Write the PNG, SVG and GIF of the solved maze, if asked by cfg
+func Output(cfg Config, m *maze.Solved) error {
+	if cfg.PNG {
+		LOGGER.Info("Start creating image result. This can take time depend on how large the maze")
+		img, err := render.CreateSolutionImage(m, cfg.Render)
+		if err != nil {
+			return err
+		}
+
+		output := cfg.ResultFilename(string(m.SearchType), "png")
+		if err = WriteResult(output, img.Bytes(), cfg.Force); err != nil {
+			return err
+		}
+		LOGGER.Info("Create image successfully", "path", output)
+	}
+
+	if cfg.SVG {
+		img, err := render.CreateSVG(m, cfg.Render)
+		if err != nil {
+			return err
+		}
+
+		output := cfg.ResultFilename(string(m.SearchType), "svg")
+		if err = WriteResult(output, img.Bytes(), cfg.Force); err != nil {
+			return err
+		}
+		LOGGER.Info("Create SVG image successfully", "path", output)
+	}
+
+	if cfg.GIF && !DEADLINE.IsZero() && time.Now().After(DEADLINE) {
+		LOGGER.Warn("GIF skipped, the -timeout is over", "algo", m.SearchType)
+	} else if cfg.GIF {
+		LOGGER.Info("Start creating GIF result. This can take time depend on how large the maze")
+		output, err := WriteGIF(cfg, m)
+		if err != nil {
+			return err
+		}
+		LOGGER.Info("Create GIF successfully", "path", output)
+	}
+
+	return nil
+}
+
+// Write the result chosen by cfg.Stdout to stdout: the JSON export, the text render or the PNG image
+func WriteStdout(cfg Config, m *maze.Solved) error {
+	var data []byte
+	switch cfg.Stdout {
+	case "json":
+		buf, err := render.CreateJSON(cfg.Input, []*maze.Solved{m})
+		if err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	case "ascii":
+		style := render.ASCIIStyle
+		if cfg.Text != nil {
+			style = *cfg.Text
+		}
+		data = []byte(render.RenderText(m, style, !cfg.Render.SolutionOnly))
+	case "png":
+		img, err := render.CreateSolutionImage(m, cfg.Render)
+		if err != nil {
+			return err
+		}
+		data = img.Bytes()
+	default:
+		return fmt.Errorf("unknown stdout result %q, supported: json, ascii, png", cfg.Stdout)
+	}
+
+	_, err := os.Stdout.Write(data)
+	return err
+}
+
+// Save the solver trace of the solved maze as a replay file
+func WriteReplay(cfg Config, m *maze.Solved) error {
+	output := cfg.ResultFilename(string(m.SearchType), "replay")
+	file, err := CreateResultFile(output, cfg.Force)
 	if err != nil {
 		return err
 	}
 
-	output = src.CreateResultFilename(".", input, string(searchType), "gif")
-	if err = os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+	if err = maze.SaveReplay(file, m); err != nil {
+		file.Close()
 		return err
 	}
 
-	src.LOGGER.Info("Create result (image, GIF) successfully", "path", output)
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	LOGGER.Info("Create replay successfully", "path", output)
 	return nil
 }
 
-func SolveAllAlgo(input string) {
-	algos := []src.Algo{
-		src.DFS, src.BFS, src.DIJKSTRA, src.GBFS, src.ASTAR,
+// Render the image and GIF of a replay file, without solving the maze again
+func RenderReplay(cfg Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
 	}
+	defer file.Close()
 
-	// Read input from file system
-	data, err := src.ReadFile(input)
+	m, err := maze.LoadReplay(file)
 	if err != nil {
-		src.LOGGER.Error("failed to read data from file", "error", err)
-		return
+		return err
+	}
+
+	// The output files are named after the replay file
+	cfg.Input = path
+	if cfg.JSON != "" {
+		if err := WriteJSON(cfg, []*maze.Solved{m}); err != nil {
+			return err
+		}
 	}
 
-	// Run the maze solving in concurrency
-	wg := sync.WaitGroup{}
+	return Output(cfg, m)
+}
 
-	for _, algo := range algos {
-		wg.Add(1)
-		go func(mazeInput string, searchType src.Algo) {
-			defer wg.Done()
+// Stream the GIF of the maze straight into its result file, and return the file path
+func WriteGIF(cfg Config, m *maze.Solved) (string, error) {
+	if err := render.CheckGIFBudget(m, cfg.Render); err != nil {
+		return "", err
+	}
 
-			// Load the maze
+	output := cfg.ResultFilename(string(m.SearchType), "gif")
+	file, err := CreateResultFile(output, cfg.Force)
+	if err != nil {
+		return "", err
+	}
 
-			maze := src.Maze{SearchType: searchType}
-			if err := maze.Load(mazeInput); err != nil {
-				src.LOGGER.Error("Failed to load maze", "algo", searchType, "error", err)
-				return
-			}
+	opts := cfg.Render
+	progress := NewProgress(fmt.Sprintf("Encoding %s GIF", m.SearchType), 0)
+	defer progress.Clear()
+	opts.OnFrame = progress.Update
 
-			// Solve maze
-			SolveWithAlgo(&maze)
+	if err = render.WriteGIF(file, m, opts); err != nil {
+		file.Close()
+		return "", err
+	}
 
-			// Create the result image
-			output := src.CreateResultFilename(".", input, string(searchType), "png")
-			src.LOGGER.Info("Start creating image result. This can take time depend on how large the maze")
-			img, err := src.CreateSolutionImage(&maze)
-			if err != nil {
-				return
-			}
+	return output, file.Close()
+}
 
-			if err = os.WriteFile(output, img.Bytes(), 0644); err != nil {
-				return
-			}
+// Write the HTML report of the solved mazes
+func Report(cfg Config, raw string, mazes []*maze.Solved) error {
+	LOGGER.Info("Start creating HTML report")
+	buf, err := render.CreateHTMLReport(cfg.Input, raw, mazes, cfg.Render, true)
+	if err != nil {
+		return err
+	}
 
-			// Output GIF
-			src.LOGGER.Info("Start creating GIF result. This can take time depend on how large the maze")
+	if err = WriteResult(cfg.Report, buf.Bytes(), cfg.Force); err != nil {
+		return err
+	}
 
-			// Create the GIF file
-			buf, err := src.CreateGIF(&maze)
-			if err != nil {
-				src.LOGGER.Error("Failed to create GIF", "algo", searchType, "error", err)
-				return
+	LOGGER.Info("Create HTML report successfully", "path", cfg.Report)
+	return nil
+}
+
+// Write the JSON export of the solved mazes
+func WriteJSON(cfg Config, mazes []*maze.Solved) error {
+	buf, err := render.CreateJSON(cfg.Input, mazes)
+	if err != nil {
+		return err
+	}
+
+	if err = WriteResult(cfg.JSON, buf.Bytes(), cfg.Force); err != nil {
+		return err
+	}
+
+	LOGGER.Info("Create JSON export successfully", "path", cfg.JSON)
+	return nil
+}
+
+// Append the metrics of the solved mazes to the CSV file, the header is only written when the file is new
+func AppendCSV(cfg Config, mazes []*maze.Solved) error {
+	file, err := os.OpenFile(cfg.CSV, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err = render.WriteCSV(file, cfg.Input, mazes, info.Size() == 0); err != nil {
+		return err
+	}
+
+	LOGGER.Info("Append CSV metrics successfully", "path", cfg.CSV)
+	return nil
+}
+
+// Write the search tree of the solved maze as a GraphViz DOT file
+func WriteDOT(cfg Config, m *maze.Solved) error {
+	buf, err := render.CreateDOT(m)
+	if err != nil {
+		return err
+	}
+
+	output := cfg.ResultFilename(string(m.SearchType), "dot")
+	if err = WriteResult(output, buf.Bytes(), cfg.Force); err != nil {
+		return err
+	}
+
+	LOGGER.Info("Create search tree DOT successfully", "path", output)
+	return nil
+}
+
+// Solve the maze of cfg with every algorithm of algos, and write the outputs of each as soon as it's done
+func SolveAllAlgo(ctx context.Context, cfg Config, algos []maze.Algo) (solve.ComparisonReport, error) {
+	data, m, err := LoadMaze(&cfg)
+	if err != nil {
+		return solve.ComparisonReport{}, err
+	}
+
+	// One bar for every algorithm, the number of open squares bounds the number of nodes expanded by each
+	progress := NewProgress("Solving", m.GetEmptySquares()*len(algos))
+	var onExpand func(algo maze.Algo, ev solve.ExpandEvent)
+	if VERBOSITY >= 2 {
+		onExpand = LogExpand
+	} else if progress != nil {
+		onExpand = func(maze.Algo, solve.ExpandEvent) { progress.Add(1) }
+	}
+
+	// Run the maze solving in concurrency, each algorithm writes its outputs as soon as it's done
+	report, err := solve.CompareAllContext(ctx, m, algos, solve.CompareOptions{
+		Heuristic:   cfg.Heuristic,
+		Solver:      cfg.SolverOptions(),
+		Timeout:     cfg.Timeout,
+		Parallelism: cfg.Parallel,
+		Runs:        cfg.Runs,
+		OnExpand:    onExpand,
+		OnSolved: func(c solve.Comparison) {
+			// The statistics are printed as a table once every algorithm is done, see PrintComparison
+			progress.Clear()
+			logStats(slog.LevelDebug, c.Solved, c.Err)
+			cfg.VerifySolution(c.Solved)
+			WriteSolved(cfg, c.Solved)
+		},
+	})
+	progress.Clear()
+	if err != nil {
+		return report, err
+	}
+	LOGGER.Info("All algos complete", "ranking", report.Ranking)
+
+	mazes := make([]*maze.Solved, len(report.Results))
+	for i, c := range report.Results {
+		mazes[i] = c.Solved
+	}
+	cfg.CheckOptimal(ctx, m, mazes)
+
+	if cfg.Text != nil {
+		for _, m := range mazes {
+			fmt.Printf("%s:\n%s\n", m.SearchType, render.RenderText(m, *cfg.Text, !cfg.Render.SolutionOnly))
+		}
+	}
+
+	if cfg.Report != "" {
+		if err := Report(cfg, data, mazes); err != nil {
+			LOGGER.Error("Failed to create HTML report", "error", err)
+		}
+	}
+
+	if cfg.JSON != "" {
+		if err := WriteJSON(cfg, mazes); err != nil {
+			LOGGER.Error("Failed to create JSON export", "error", err)
+		}
+	}
+
+	if cfg.CSV != "" {
+		if err := AppendCSV(cfg, mazes); err != nil {
+			LOGGER.Error("Failed to append CSV metrics", "error", err)
+		}
+	}
+
+	if cfg.History != "" {
+		if err := RecordHistory(cfg, data, mazes); err != nil {
+			LOGGER.Error("Failed to record history", "error", err)
+		}
+	}
+
+	if cfg.Sheet {
+		buf, err := render.CreateContactSheet(mazes, cfg.Render)
+		if err != nil {
+			LOGGER.Error("Failed to create comparison sheet", "error", err)
+			return report, nil
+		}
+
+		output := cfg.ResultFilename("comparison", "png")
+		if err = WriteResult(output, buf.Bytes(), cfg.Force); err != nil {
+			LOGGER.Error("Failed to write comparison sheet to file system", "error", err)
+			return report, nil
+		}
+
+		LOGGER.Info("Create comparison sheet successfully", "path", output)
+	}
+
+	return report, nil
+}
+
+// Get the solve time of an algorithm of a comparison, the mean of its runs when there are several
+func meanSolveTime(c solve.Comparison) time.Duration {
+	if len(c.Runs.Times) > 1 {
+		return c.Runs.Mean()
+	}
+
+	return c.SolveTime
+}
+
+// Print the outcome of every algorithm of a comparison as an aligned table, in the order they were given. The
+// cheapest solutions are marked optimal and the quickest solve fastest. With several runs, the time is their mean and
+// standard deviation
+func PrintComparison(w io.Writer, report solve.ComparisonReport) {
+	bestCost, bestTime := -1, time.Duration(-1)
+	for _, c := range report.Results {
+		if c.Err != nil {
+			continue
+		}
+		if bestCost < 0 || c.PathCost < bestCost {
+			bestCost = c.PathCost
+		}
+		if t := meanSolveTime(c); bestTime < 0 || t < bestTime {
+			bestTime = t
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Algorithm\tSolved\tPath length\tPath cost\tExpanded\tCoverage\tTime\t")
+	for _, c := range report.Results {
+		solved, marks := "yes", []string{}
+		if c.Err != nil {
+			solved = "no: " + c.Err.Error()
+		} else {
+			if c.PathCost == bestCost {
+				marks = append(marks, "optimal")
 			}
+			// Every time is 0 in deterministic mode, so no solve is faster than the others
+			if meanSolveTime(c) == bestTime && !maze.Deterministic {
+				marks = append(marks, "fastest")
+			}
+		}
+		if len(c.Runs.Times) > 1 && !c.Runs.SamePath {
+			marks = append(marks, "paths differ between runs")
+		}
+
+		coverage := 0.0
+		if open := c.Maze.GetEmptySquares(); open > 0 {
+			coverage = 100 * float64(c.Expanded) / float64(open)
+		}
+
+		elapsed := meanSolveTime(c).Round(time.Microsecond).String()
+		if len(c.Runs.Times) > 1 {
+			elapsed += " ± " + c.Runs.StdDev().Round(time.Microsecond).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%.1f%%\t%s\t%s\n", c.SearchType, solved, c.PathLength, c.PathCost,
+			c.Expanded, coverage, elapsed, strings.Join(marks, ", "))
+	}
+	tw.Flush()
+}
+
+// Print the verdict of a comparison: which algorithms found the cheapest path, expanded the fewest nodes and solved
+// the fastest, then the ranking and the recommended algorithm, which is the first of the ranking
+func PrintVerdict(w io.Writer, report solve.ComparisonReport) {
+	var solved []solve.Comparison
+	for _, c := range report.Results {
+		if c.Err == nil {
+			solved = append(solved, c)
+		}
+	}
 
-			// Write to file system
-			output = src.CreateResultFilename(".", input, string(searchType), "gif")
-			if err = os.WriteFile(output, buf.Bytes(), 0644); err != nil {
-				src.LOGGER.Error("Failed to write GIF result to file system", "algo", searchType, "error", err)
+	fmt.Fprintln(w, "Verdict:")
+	if len(solved) == 0 {
+		fmt.Fprintln(w, "  No algorithm found a path")
+		return
+	}
+
+	// Every algorithm tied for the best value of measure
+	best := func(measure func(c solve.Comparison) int64) (int64, string) {
+		value := measure(slices.MinFunc(solved, func(a, b solve.Comparison) int { return cmp.Compare(measure(a), measure(b)) }))
+		var algos []string
+		for _, c := range solved {
+			if measure(c) == value {
+				algos = append(algos, string(c.SearchType))
 			}
+		}
+		return value, strings.Join(algos, ", ")
+	}
 
-			src.LOGGER.Info("Create GIF successfully", "path", output)
-		}(data, algo)
+	cost, algos := best(func(c solve.Comparison) int64 { return int64(c.PathCost) })
+	fmt.Fprintf(w, "  Cheapest path (cost %d): %s\n", cost, algos)
+	expanded, algos := best(func(c solve.Comparison) int64 { return int64(c.Expanded) })
+	fmt.Fprintf(w, "  Fewest expanded nodes (%d): %s\n", expanded, algos)
+	if !maze.Deterministic {
+		fastest, algos := best(func(c solve.Comparison) int64 { return int64(meanSolveTime(c)) })
+		fmt.Fprintf(w, "  Fastest (%s): %s\n", time.Duration(fastest).Round(time.Microsecond), algos)
 	}
 
-	wg.Wait()
-	src.LOGGER.Info("All algos complete")
+	ranking := make([]string, len(report.Ranking))
+	for i, algo := range report.Ranking {
+		ranking[i] = string(algo)
+	}
+	fmt.Fprintf(w, "  Ranking: %s\n", strings.Join(ranking, " > "))
+	fmt.Fprintf(w, "  Recommended: %s, the cheapest path with the fewest expanded nodes\n", report.Ranking[0])
 }
 
-func main() {
-	// Get the parameters
-	var input, searchType string
-	flag.StringVar(&input, "maze", "mazes/maze.txt", "The maze input file")
-	flag.StringVar(&searchType, "search", "", "The search algorithm") // If empty, solve the maze with all algorithms
-	flag.Parse()
+// Read and load the maze of cfg with the movement of the run, and precompute its heuristic if needed.
+// Return the maze text too, which the HTML report embeds
+func LoadMaze(cfg *Config) (string, *maze.Maze, error) {
+	data, err := ReadFile(cfg.Input)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read maze: %v", err)
+	}
 
-	// Check for searchType value
-	switch searchType {
-	case "":
-		SolveAllAlgo(input)
-	default:
-		if !src.IsAlgo(searchType) {
-			src.LOGGER.Warn("Unsupported algorithm")
-			return
+	// The maze is loaded once, the solvers and renderers only read it so every goroutine can share it
+	m := &maze.Maze{Strict: cfg.Strict, MaxCells: cfg.MaxCells}
+	if err := m.Load(data); err != nil {
+		return "", nil, err
+	}
+	m.Topology = cfg.Topology
+
+	if err := cfg.PrecomputeHeuristic(m); err != nil {
+		return "", nil, fmt.Errorf("failed to precompute the distance field: %v", err)
+	}
+
+	return data, m, nil
+}
+
+// Solve the maze of cfg with algo, and write its outputs
+func SolveOne(ctx context.Context, cfg Config, algo maze.Algo) error {
+	data, m, err := LoadMaze(&cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := cfg.SolveContext(ctx)
+	defer cancel()
+
+	solver, err := NewSolver(cfg, m, algo)
+	if err != nil {
+		return err
+	}
+	if cfg.Resume != "" {
+		if err := ResumeSolver(cfg, solver); err != nil {
+			return fmt.Errorf("failed to resume from snapshot: %v", err)
+		}
+	}
+
+	var solved *maze.Solved
+	if cfg.Live > 0 {
+		solved = SolveLive(ctx, cfg, solver, m, algo)
+	} else if cfg.Explain {
+		solved = SolveExplained(ctx, cfg, solver, m, algo)
+	} else {
+		// The number of open squares bounds the number of expanded nodes
+		open := m.GetEmptySquares()
+		progress := NewProgress(fmt.Sprintf("Solving with %s", algo), open)
+		if progress != nil {
+			solver.OnExpand(func(ev solve.ExpandEvent) { progress.Update(ev.Explored, open) })
 		}
-		// Read input from file system
-		data, err := src.ReadFile(input)
+		solved = Solve(ctx, cfg, solver, m, algo)
+		progress.Clear()
+	}
+
+	if cfg.Runs > 1 {
+		runs, err := solve.Repeat(ctx, m, algo, cfg.Heuristic, cfg.SolverOptions(), solved.Result, cfg.Runs)
 		if err != nil {
-			src.LOGGER.Error("failed to read data from file", "error", err)
-			return
+			return err
 		}
+		LogRuns(algo, runs)
+	}
+	cfg.VerifySolution(solved)
+	cfg.CheckOptimal(ctx, m, []*maze.Solved{solved})
 
-		algo := src.Algo(searchType)
-		maze := src.Maze{SearchType: algo}
-		if err := maze.Load(data); err != nil {
-			src.LOGGER.Error("Failed to load maze", "error", err)
-			return
+	if cfg.Snapshot != "" {
+		if err := WriteSnapshot(cfg, solver); err != nil {
+			LOGGER.Error("Failed to save snapshot", "error", err)
 		}
+	}
 
-		SolveWithAlgo(&maze)
+	if cfg.DOT {
+		if err := WriteDOT(cfg, solved); err != nil {
+			LOGGER.Error("Failed to create search tree DOT", "error", err)
+		}
+	}
 
-		fmt.Print("Do you want to ouput GIF (y/n): ")
-		var confirm string
-		fmt.Scanln(&confirm)
+	if cfg.Replay {
+		if err := WriteReplay(cfg, solved); err != nil {
+			LOGGER.Error("Failed to create replay", "error", err)
+		}
+	}
 
-		if confirm == "y" {
-			if err := Output(input, maze.SearchType, &maze); err != nil {
-				src.LOGGER.Error("Failed to output results", "error", err)
-				return
-			}
+	if cfg.Report != "" {
+		if err := Report(cfg, data, []*maze.Solved{solved}); err != nil {
+			LOGGER.Error("Failed to create HTML report", "error", err)
 		}
 	}
+
+	if cfg.JSON != "" {
+		if err := WriteJSON(cfg, []*maze.Solved{solved}); err != nil {
+			LOGGER.Error("Failed to create JSON export", "error", err)
+		}
+	}
+
+	if cfg.CSV != "" {
+		if err := AppendCSV(cfg, []*maze.Solved{solved}); err != nil {
+			LOGGER.Error("Failed to append CSV metrics", "error", err)
+		}
+	}
+
+	// Recorded once the outputs below are written, so they are in the artifacts of the run
+	if cfg.History != "" {
+		defer func() {
+			if err := RecordHistory(cfg, data, []*maze.Solved{solved}); err != nil {
+				LOGGER.Error("Failed to record history", "error", err)
+			}
+		}()
+	}
+
+	if cfg.Stdout != "" {
+		return WriteStdout(cfg, solved)
+	}
+
+	// The live view has already shown the result, and it keeps reading the keys from stdin
+	if cfg.Live > 0 {
+		return nil
+	}
+
+	if cfg.Text != nil {
+		fmt.Print(render.RenderText(solved, *cfg.Text, !cfg.Render.SolutionOnly))
+		return nil
+	}
+
+	if err := Output(cfg, solved); err != nil {
+		LOGGER.Error("Failed to output results", "error", err)
+	}
+
+	return nil
+}
+
+func main() {
+	// The global flags come before the command, the flags of the command after it
+	flag.Usage = func() {
+		Usage(os.Stderr)
+	}
+	logFormat := flag.String("log-format", "text", "The format of the logs: text or json")
+	logLevel := flag.String("log-level", "info", "The lowest level of the logs shown: debug, info, warn or error")
+	quiet := flag.Bool("q", false, "Quiet: only log errors, and print one line with the answer of each solve instead of the solution")
+	verbose := flag.Bool("v", false, "Verbose: log debug events, same as -log-level debug")
+	veryVerbose := flag.Bool("vv", false, "Very verbose: like -v, and log every expanded node")
+	timeout := flag.Duration("timeout", 0, "Stop the solves still running after this long (e.g. 30s) and write the outputs from\n"+
+		"their partial results, without the GIFs. 0 means no limit. The -timeout of a command limits each of its solves")
+	flag.Parse()
+
+	if *timeout > 0 {
+		DEADLINE = time.Now().Add(*timeout)
+	}
+
+	switch {
+	case *quiet && (*verbose || *veryVerbose):
+		fmt.Fprintln(os.Stderr, "-q can't be used with -v or -vv")
+		os.Exit(2)
+	case *quiet:
+		VERBOSITY, *logLevel = -1, "error"
+	case *veryVerbose:
+		VERBOSITY, *logLevel = 2, "debug"
+	case *verbose:
+		VERBOSITY, *logLevel = 1, "debug"
+	}
+
+	logger, err := NewLogger(LOG_OUTPUT, *logFormat, *logLevel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// The library packages log through the default logger
+	LOGGER = logger
+	slog.SetDefault(LOGGER)
+
+	if flag.NArg() == 0 {
+		Usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	name, args := flag.Arg(0), flag.Args()[1:]
+	if name == "help" {
+		Usage(os.Stdout)
+		return
+	}
+
+	command, ok := CommandByName(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		Usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	// The flags of the command print their help themselves
+	if err := command.Run(args); errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		LOGGER.Error("Command failed", "command", name, "error", err)
+		os.Exit(1)
+	}
 }
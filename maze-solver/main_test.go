@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// Every algorithm, the parallel one included
+var everyAlgo = slices.Concat(solve.AllAlgos, []maze.Algo{maze.PARALLEL_DIJKSTRA})
+
+// Solve a 1x3 maze with algo, its start at the left end and its goal in the column goal
+func solveRow(t *testing.T, algo maze.Algo, goal int) (*maze.Solved, error) {
+	t.Helper()
+
+	m, err := maze.NewMazeBuilder(1, 3).SetStart(maze.Point{}).SetGoal(maze.Point{Col: goal}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	solver, err := solve.NewSolverForAlgo(algo, m, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := solver.Solve()
+	return &maze.Solved{Maze: m, SearchType: algo, Result: result}, err
+}
+
+func TestSolutionTextStartIsGoal(t *testing.T) {
+	for _, algo := range everyAlgo {
+		solved, err := solveRow(t, algo, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", algo, err)
+		}
+
+		if !solved.Found() {
+			t.Errorf("%s: a start that is the goal isn't found", algo)
+		}
+		if got, want := solutionText(solved, err), "the start is the goal, no moves required"; got != want {
+			t.Errorf("%s: got %q, want %q", algo, got, want)
+		}
+	}
+}
+
+func TestSolutionTextGoalNextToStart(t *testing.T) {
+	for _, algo := range everyAlgo {
+		solved, err := solveRow(t, algo, 1)
+		if err != nil {
+			t.Fatalf("%s: %v", algo, err)
+		}
+
+		if got, want := solutionText(solved, err), "Start, move right to (0, 1), reach goal."; got != want {
+			t.Errorf("%s: got %q, want %q", algo, got, want)
+		}
+	}
+}
+
+func TestSolutionTextNoPath(t *testing.T) {
+	unreachable := &maze.Solved{Maze: &maze.Maze{}, Result: maze.Result{Unreachable: true}}
+	if got, want := solutionText(unreachable, solve.ErrNoSolution), "none, the goal can't be reached from the start"; got != want {
+		t.Errorf("unreachable: got %q, want %q", got, want)
+	}
+
+	stopped := &maze.Solved{Maze: &maze.Maze{Start: maze.Point{}, Goal: maze.Point{Col: 2}}}
+	got := solutionText(stopped, errors.New("search stopped after expanding 1 nodes, the node limit"))
+	if want := "none, the search has stopped: search stopped after expanding 1 nodes, the node limit"; got != want {
+		t.Errorf("stopped: got %q, want %q", got, want)
+	}
+}
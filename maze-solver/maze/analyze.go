@@ -0,0 +1,146 @@
+package maze
+
+import "math"
+
+// The shape of a maze, measured without solving it. The neighbors of a square are the open squares its topology moves to
+type Analysis struct {
+	Height          int     `json:"height"`
+	Width           int     `json:"width"`
+	OpenSquares     int     `json:"open_squares"`
+	WallDensity     float64 `json:"wall_density"`     // Fraction of the squares that are walls
+	DeadEnds        int     `json:"dead_ends"`        // Open squares with a single neighbor, the start and goal excluded
+	Junctions       int     `json:"junctions"`        // Open squares with 3 neighbors or more
+	BranchingFactor float64 `json:"branching_factor"` // Mean number of neighbors of the open squares
+	Loops           int     `json:"loops"`            // Independent cycles of the open squares: edges - squares + connected parts
+	LongestCorridor int     `json:"longest_corridor"` // Most squares in a row with exactly 2 neighbors each
+	Reachable       bool    `json:"reachable"`        // The goal can be reached from the start
+	ShortestSteps   int     `json:"shortest_steps"`   // Fewest moves from the start to the goal ignoring costs, 0 when unreachable
+	Difficulty      int     `json:"difficulty"`       // A rough score from 0 (trivial) to 100, see Analyze
+}
+
+// Measure the shape of the maze. The difficulty weighs how winding the shortest route is (0.6) against how many dead
+// ends lure a search away from it (0.4): 100 * (0.6 * (1 - straight distance / shortest steps) + 0.4 * min(1, 4 * dead
+// ends / open squares)). The straight distance is the Manhattan distance, or the Chebyshev one with eight-way moves.
+// A maze whose goal can't be reached scores 100
+func (maze *Maze) Analyze() Analysis {
+	a := Analysis{Height: maze.Height, Width: maze.Width, OpenSquares: maze.GetEmptySquares()}
+	if total := maze.Height * maze.Width; total > 0 {
+		a.WallDensity = float64(total-a.OpenSquares) / float64(total)
+	}
+
+	degrees := make(map[Point]int, a.OpenSquares)
+	edges := 0
+	for i := range maze.Height {
+		for j := range maze.Width {
+			p := Point{Row: i, Col: j}
+			if !maze.IsOpen(p) {
+				continue
+			}
+
+			degree := len(maze.Neighbors(&Node{Square: maze.Square(p)}))
+			degrees[p] = degree
+			edges += degree
+			switch {
+			case degree == 1 && p != maze.Start && p != maze.Goal:
+				a.DeadEnds++
+			case degree >= 3:
+				a.Junctions++
+			}
+		}
+	}
+	if a.OpenSquares > 0 {
+		a.BranchingFactor = float64(edges) / float64(a.OpenSquares)
+	}
+
+	// Every move is counted from both of its squares
+	a.Loops = edges/2 - a.OpenSquares + maze.components()
+
+	// The corridors are the connected parts of the squares with 2 neighbors
+	corridor := func(p Point) bool { return degrees[p] == 2 }
+	seen := map[Point]bool{}
+	for p, degree := range degrees {
+		if degree != 2 || seen[p] {
+			continue
+		}
+
+		squares := maze.flood(p, corridor, nil)
+		for q := range squares {
+			seen[q] = true
+		}
+		a.LongestCorridor = max(a.LongestCorridor, len(squares))
+	}
+
+	distances := map[Point]int{}
+	maze.flood(maze.Start, everySquare, distances)
+	a.ShortestSteps, a.Reachable = distances[maze.Goal]
+	a.Difficulty = 100
+	if a.Reachable && a.ShortestSteps > 0 {
+		dRow, dCol := Abs(maze.Goal.Row-maze.Start.Row), Abs(maze.Goal.Col-maze.Start.Col)
+		straight := dRow + dCol
+		if _, ok := maze.Topology.(EightWay); ok {
+			straight = max(dRow, dCol)
+		}
+
+		winding := 1 - float64(straight)/float64(a.ShortestSteps)
+		deadEnds := math.Min(1, 4*float64(a.DeadEnds)/float64(a.OpenSquares))
+		a.Difficulty = int(math.Round(100 * (0.6*winding + 0.4*deadEnds)))
+	} else if a.Reachable {
+		a.Difficulty = 0
+	}
+
+	return a
+}
+
+// Accept every open square in flood
+func everySquare(Point) bool { return true }
+
+// Get the open squares connected to from through the squares accepted by keep, from included if kept. When distances
+// isn't nil, it gets the fewest moves from from to every square found
+func (maze *Maze) flood(from Point, keep func(Point) bool, distances map[Point]int) map[Point]bool {
+	seen := map[Point]bool{}
+	if !maze.IsOpen(from) || !keep(from) {
+		return seen
+	}
+
+	seen[from] = true
+	if distances != nil {
+		distances[from] = 0
+	}
+	queue := []Point{from}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, next := range maze.Neighbors(&Node{Square: maze.Square(p)}) {
+			if q := next.Square.Coordinate; !seen[q] && keep(q) {
+				seen[q] = true
+				if distances != nil {
+					distances[q] = distances[p] + 1
+				}
+				queue = append(queue, q)
+			}
+		}
+	}
+
+	return seen
+}
+
+// Count the connected parts of the open squares
+func (maze *Maze) components() int {
+	seen := map[Point]bool{}
+	count := 0
+	for i := range maze.Height {
+		for j := range maze.Width {
+			p := Point{Row: i, Col: j}
+			if seen[p] || !maze.IsOpen(p) {
+				continue
+			}
+
+			count++
+			for q := range maze.flood(p, everySquare, nil) {
+				seen[q] = true
+			}
+		}
+	}
+
+	return count
+}
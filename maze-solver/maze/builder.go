@@ -0,0 +1,117 @@
+package maze
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Build a maze square by square instead of parsing its text. Every square starts open with a cost of 1.
+// The first invalid call is remembered and returned by Build, so the calls can be chained
+type MazeBuilder struct {
+	maze        Maze
+	start, goal *Point
+	err         error
+}
+
+// Create a builder of a maze with height rows and width columns
+func NewMazeBuilder(height, width int) *MazeBuilder {
+	b := &MazeBuilder{maze: Maze{Height: height, Width: width}}
+	if height <= 0 || width <= 0 {
+		b.err = ErrInvalidMaze{Row: -1, Col: -1, Reason: fmt.Sprintf("the size %dx%d is empty", height, width)}
+		return b
+	}
+
+	b.maze.squares = newSquares(height, width)
+	for i := range b.maze.costs {
+		b.maze.costs[i] = 1
+	}
+
+	return b
+}
+
+// Turn the square at p into a wall
+func (b *MazeBuilder) SetWall(p Point) *MazeBuilder {
+	if b.check(p) {
+		b.maze.setSquare(p, true, 0)
+	}
+
+	return b
+}
+
+// Open the square at p with the cost c. Like in the text format, the cost goes from 1 to 9
+func (b *MazeBuilder) SetCost(p Point, c int) *MazeBuilder {
+	if !b.check(p) {
+		return b
+	}
+
+	switch {
+	case c < 1:
+		b.err = ErrUnsupportedCost{Row: p.Row, Col: p.Col, Cost: c}
+	case c > 9:
+		b.err = ErrInvalidMaze{Row: p.Row, Col: p.Col, Reason: fmt.Sprintf("cost %d can't be written as a single digit", c)}
+	default:
+		b.maze.setSquare(p, false, c)
+	}
+
+	return b
+}
+
+// Set the start of the maze
+func (b *MazeBuilder) SetStart(p Point) *MazeBuilder {
+	if b.check(p) {
+		b.start = &p
+	}
+
+	return b
+}
+
+// Set the goal of the maze
+func (b *MazeBuilder) SetGoal(p Point) *MazeBuilder {
+	if b.check(p) {
+		b.goal = &p
+	}
+
+	return b
+}
+
+// Create the maze, which is checked like a loaded maze would be. The builder can keep being used afterward,
+// it doesn't change the built maze
+func (b *MazeBuilder) Build() (*Maze, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.start == nil || b.goal == nil {
+		return nil, ErrInvalidMaze{Row: -1, Col: -1, Reason: "need both starting and ending position for the maze"}
+	}
+
+	m := &Maze{Height: b.maze.Height, Width: b.maze.Width, Start: *b.start, Goal: *b.goal}
+	m.squares = squares{walls: slices.Clone(b.maze.walls), costs: slices.Clone(b.maze.costs)}
+
+	// The start and goal always cost 1, like in a loaded maze
+	for _, p := range []Point{m.Start, m.Goal} {
+		if m.IsOpen(p) {
+			m.setSquare(p, false, 1)
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Check that p is inside the maze, and remember the error if not. Return false if the call can't be applied
+func (b *MazeBuilder) check(p Point) bool {
+	if b.err != nil {
+		return false
+	}
+
+	if !b.maze.Inside(p) {
+		b.err = ErrInvalidMaze{Row: p.Row, Col: p.Col, Reason: fmt.Sprintf("outside of the %dx%d maze", b.maze.Height, b.maze.Width)}
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,22 @@
+package maze
+
+import "time"
+
+// Make two runs on the same maze produce byte-identical outputs, e.g. for golden files. When set:
+//   - solvers break cost ties in the order the nodes were generated, instead of leaving it to the priority queue
+//   - the numbers that change between runs (solve time and allocated bytes) are left at zero
+//   - Now returns a fixed time, so timestamps in reports, CSV rows and file names don't change
+//   - the algorithms compared by solve.CompareAll are solved one after the other, in the given order
+//
+// Nothing in the module uses random numbers, so there is no seed to fix.
+// Set it once before solving, it is read without synchronization
+var Deterministic bool
+
+// The current time, or the Unix epoch in deterministic mode
+func Now() time.Time {
+	if Deterministic {
+		return time.Unix(0, 0).UTC()
+	}
+
+	return time.Now()
+}
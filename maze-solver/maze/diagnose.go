@@ -0,0 +1,138 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// How serious a problem found by Diagnose is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // The maze can't be loaded or solved
+	SeverityWarning Severity = "warning" // The maze can be solved, but probably not as intended
+)
+
+// A problem found in the text of a maze. Row and Col locate it, they are -1 when it isn't about one square
+type Diagnostic struct {
+	Row, Col int
+	Severity Severity
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	if d.Row < 0 || d.Col < 0 {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+
+	return fmt.Sprintf("%s at row %d, column %d: %s", d.Severity, d.Row, d.Col, d.Message)
+}
+
+// Check the text of a maze without solving it, and report every problem found instead of stopping at the first one:
+// invalid characters, missing or duplicate start and goal, rows of different lengths, costs the solvers can't handle
+// and a goal that can't be reached from the start with the topology. The maze loaded from the text is returned when it
+// could be loaded, nil otherwise
+func Diagnose(text string, topology Topology) (*Maze, []Diagnostic) {
+	var diags []Diagnostic
+	report := func(row, col int, severity Severity, format string, args ...any) {
+		diags = append(diags, Diagnostic{Row: row, Col: col, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	lines, _ := mazeLines(text)
+	data := strings.Join(lines, "\n")
+	if data == "" {
+		report(-1, -1, SeverityError, "the maze is empty")
+		return nil, diags
+	}
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line))
+	}
+
+	var starts, goals []Point
+	for i, line := range lines {
+		if len(line) < width {
+			report(i, len(line), SeverityWarning, "the row is %d squares long instead of %d, the rest is filled with walls", len(line), width)
+		}
+
+		for j := 0; j < len(line); j++ {
+			switch c := line[j]; {
+			case c == 'A':
+				starts = append(starts, Point{Row: i, Col: j})
+			case c == 'B':
+				goals = append(goals, Point{Row: i, Col: j})
+			case c != ' ' && c != '#' && !('1' <= c && c <= '9'):
+				r, _ := utf8.DecodeRuneInString(line[j:])
+				report(i, j, SeverityError, "invalid character %q", r)
+			}
+		}
+	}
+
+	for _, square := range []struct {
+		name   string
+		points []Point
+	}{{"start (A)", starts}, {"goal (B)", goals}} {
+		name, points := square.name, square.points
+		if len(points) == 0 {
+			report(-1, -1, SeverityError, "the %s is missing", name)
+		}
+		for _, p := range points[min(len(points), 1):] {
+			report(p.Row, p.Col, SeverityError, "duplicate %s, the first one is at row %d, column %d", name, points[0].Row, points[0].Col)
+		}
+	}
+
+	sortDiagnostics(diags)
+	if hasErrors(diags) {
+		return nil, diags
+	}
+
+	m := &Maze{Topology: topology}
+	if err := m.Load(data); err != nil {
+		report(-1, -1, SeverityError, "%v", err)
+		return nil, diags
+	}
+
+	if err := m.Validate(); err != nil {
+		var invalid ErrInvalidMaze
+		var cost ErrUnsupportedCost
+		switch {
+		case errors.As(err, &invalid):
+			report(invalid.Row, invalid.Col, SeverityError, "%s", invalid.Reason)
+		case errors.As(err, &cost):
+			report(cost.Row, cost.Col, SeverityError, "unsupported cost %d, the cost of a square must be at least 1", cost.Cost)
+		default:
+			report(-1, -1, SeverityError, "%v", err)
+		}
+		return m, diags
+	}
+
+	if !m.Reachable(m.Start, m.Goal) {
+		report(m.Goal.Row, m.Goal.Col, SeverityError, "the goal can't be reached from the start at row %d, column %d", m.Start.Row, m.Start.Col)
+	}
+
+	return m, diags
+}
+
+// Check if to can be reached from from by moving through open squares with the topology of the maze
+func (maze *Maze) Reachable(from, to Point) bool {
+	return maze.IsOpen(to) && maze.flood(from, everySquare, nil)[to]
+}
+
+// Sort the diagnostics by position, the ones about the whole maze first
+func sortDiagnostics(diags []Diagnostic) {
+	slices.SortStableFunc(diags, func(a, b Diagnostic) int {
+		if a.Row != b.Row {
+			return a.Row - b.Row
+		}
+		return a.Col - b.Col
+	})
+}
+
+// Check if any of the diagnostics is an error
+func hasErrors(diags []Diagnostic) bool {
+	return slices.ContainsFunc(diags, func(d Diagnostic) bool { return d.Severity == SeverityError })
+}
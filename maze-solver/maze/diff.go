@@ -0,0 +1,35 @@
+package maze
+
+// A square that differs between two mazes
+type CellChange struct {
+	Point  Point
+	Before Square // The square in the maze Diff is called on
+	After  Square // The square in the other maze
+}
+
+// Whether the maze has the same size, start, goal and squares as other. The topology is how the maze is solved,
+// not what it is, so it's not compared
+func (maze *Maze) Equal(other *Maze) bool {
+	if maze.Height != other.Height || maze.Width != other.Width || maze.Start != other.Start || maze.Goal != other.Goal {
+		return false
+	}
+
+	return len(maze.Diff(other)) == 0
+}
+
+// Get every square that changes from the maze to other, row by row. When the sizes differ, the squares outside
+// of one maze are walls in it. The start and goal are not squares, compare them directly
+func (maze *Maze) Diff(other *Maze) []CellChange {
+	var changes []CellChange
+	for i := range max(maze.Height, other.Height) {
+		for j := range max(maze.Width, other.Width) {
+			p := Point{Row: i, Col: j}
+			before, after := maze.Square(p), other.Square(p)
+			if before != after {
+				changes = append(changes, CellChange{Point: p, Before: before, After: after})
+			}
+		}
+	}
+
+	return changes
+}
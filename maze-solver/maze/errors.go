@@ -0,0 +1,79 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Returned when a maze can't be loaded or solved because of its content. Row and Col locate the problem, they are -1
+// when it isn't about one square (e.g. the start is missing)
+type ErrInvalidMaze struct {
+	Row, Col int
+	Reason   string
+}
+
+func (err ErrInvalidMaze) Error() string {
+	if err.Row < 0 || err.Col < 0 {
+		return fmt.Sprintf("invalid maze: %s", err.Reason)
+	}
+
+	return fmt.Sprintf("invalid maze at row %d, column %d: %s", err.Row, err.Col, err.Reason)
+}
+
+// Returned by Load when a maze has more squares (height x width) than its MaxCells, before they are allocated
+type ErrTooLarge struct {
+	Height, Width int
+	Limit         int
+}
+
+func (err ErrTooLarge) Error() string {
+	return fmt.Sprintf("maze has %s squares (%dx%d), exceeds the limit of %s", shortCount(err.Height*err.Width),
+		err.Height, err.Width, shortCount(err.Limit))
+}
+
+// Write a count the short way, rounded to one decimal: 950, 12.5K or 400M
+func shortCount(n int) string {
+	for _, unit := range []struct {
+		size   float64
+		suffix string
+	}{{1e9, "G"}, {1e6, "M"}, {1e3, "K"}} {
+		if float64(n) >= unit.size {
+			return strconv.FormatFloat(math.Round(float64(n)/unit.size*10)/10, 'f', -1, 64) + unit.suffix
+		}
+	}
+
+	return strconv.Itoa(n)
+}
+
+// Returned when an open square has a cost the solvers can't handle. Every algorithm expects a cost of at least 1
+type ErrUnsupportedCost struct {
+	Row, Col int
+	Cost     int
+}
+
+func (err ErrUnsupportedCost) Error() string {
+	return fmt.Sprintf("unsupported cost %d at row %d, column %d, the cost of a square must be at least 1", err.Cost, err.Row, err.Col)
+}
+
+// Check that the maze can be solved: the start and the goal are open squares inside the maze, and every open square
+// costs at least 1
+func (maze *Maze) Validate() error {
+	if !maze.IsOpen(maze.Start) {
+		return ErrInvalidMaze{Row: maze.Start.Row, Col: maze.Start.Col, Reason: "the start is not an open square"}
+	}
+
+	if !maze.IsOpen(maze.Goal) {
+		return ErrInvalidMaze{Row: maze.Goal.Row, Col: maze.Goal.Col, Reason: "the goal is not an open square"}
+	}
+
+	for i := range maze.Height {
+		for j := range maze.Width {
+			if sq := maze.Square(Point{Row: i, Col: j}); !sq.IsWall && sq.Cost < 1 {
+				return ErrUnsupportedCost{Row: i, Col: j, Cost: sq.Cost}
+			}
+		}
+	}
+
+	return nil
+}
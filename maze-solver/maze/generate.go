@@ -0,0 +1,93 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Options of Generate
+type GenerateOptions struct {
+	Seed     int64   // The same seed always gives the same maze
+	Loops    float64 // Fraction of the inner walls knocked down to open loops, 0 gives a perfect maze (a single path between two squares)
+	Weighted bool    // Give every open square a random cost from 1 to 9
+}
+
+// Generate a maze of height rows and width columns with a randomized depth-first search. The squares at odd rows and
+// columns are the rooms, which are joined by opening the wall between them. The start is the top-left room, the
+// goal the bottom-right one, so a maze with a single room (less than 5 rows and 5 columns) starts at its goal
+func Generate(height, width int, opts GenerateOptions) (*Maze, error) {
+	if height < 3 || width < 3 {
+		return nil, fmt.Errorf("cannot generate a %dx%d maze, it needs at least 3 rows and 3 columns", height, width)
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed))
+	open := make([][]bool, height)
+	for i := range open {
+		open[i] = make([]bool, width)
+	}
+
+	// The rooms are at odd coordinates, the last room can't be on the border
+	rows, cols := (height-1)/2, (width-1)/2
+	room := func(i, j int) Point {
+		return Point{Row: 2*i + 1, Col: 2*j + 1}
+	}
+
+	// Carve the paths from the first room, going back when a room has no unvisited neighbor
+	visited := make([][]bool, rows)
+	for i := range visited {
+		visited[i] = make([]bool, cols)
+	}
+	stack := []Point{{0, 0}}
+	visited[0][0] = true
+	open[1][1] = true
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		var next []Point
+		for _, mv := range fourWayMoves {
+			p := Point{Row: current.Row + mv.Row, Col: current.Col + mv.Col}
+			if 0 <= p.Row && p.Row < rows && 0 <= p.Col && p.Col < cols && !visited[p.Row][p.Col] {
+				next = append(next, p)
+			}
+		}
+
+		if len(next) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		p := next[r.Intn(len(next))]
+		visited[p.Row][p.Col] = true
+		from, to := room(current.Row, current.Col), room(p.Row, p.Col)
+		open[(from.Row+to.Row)/2][(from.Col+to.Col)/2] = true
+		open[to.Row][to.Col] = true
+		stack = append(stack, p)
+	}
+
+	// Knock down some of the walls between two rooms to open loops
+	if opts.Loops > 0 {
+		for i := 1; i < 2*rows; i++ {
+			for j := 1; j < 2*cols; j++ {
+				between := (i%2 == 1) != (j%2 == 1)
+				if !open[i][j] && between && r.Float64() < opts.Loops {
+					open[i][j] = true
+				}
+			}
+		}
+	}
+
+	b := NewMazeBuilder(height, width)
+	for i := range open {
+		for j := range open[i] {
+			p := Point{Row: i, Col: j}
+			switch {
+			case !open[i][j]:
+				b.SetWall(p)
+			case opts.Weighted:
+				b.SetCost(p, 1+r.Intn(9))
+			}
+		}
+	}
+
+	return b.SetStart(room(0, 0)).SetGoal(room(rows-1, cols-1)).Build()
+}
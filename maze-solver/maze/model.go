@@ -0,0 +1,430 @@
+// Package maze holds the maze model: parsing, squares, nodes, the solution and everything a solver records.
+package maze
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Constant definitions
+type Algo string
+
+type Action string
+
+const (
+	BFS      Algo = "bfs"
+	DFS      Algo = "dfs"
+	GBFS     Algo = "gbfs"
+	ASTAR    Algo = "astar"
+	DIJKSTRA Algo = "dijkstra"
+
+	PARALLEL_DIJKSTRA Algo = "parallel-dijkstra"
+
+	UP    Action = "up"
+	DOWN  Action = "down"
+	LEFT  Action = "left"
+	RIGHT Action = "right"
+	NONE  Action = "none"
+
+	UP_LEFT    Action = "up-left"
+	UP_RIGHT   Action = "up-right"
+	DOWN_LEFT  Action = "down-left"
+	DOWN_RIGHT Action = "down-right"
+)
+
+func IsAlgo(algo string) bool {
+	a := Algo(algo)
+	return a == BFS || a == DFS || a == GBFS || a == ASTAR || a == DIJKSTRA || a == PARALLEL_DIJKSTRA
+}
+
+// The Coordinate struct
+type Point struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Square in the maze, which can be either empty (can move to) and wall (cannot move to)
+type Square struct {
+	Coordinate Point
+	IsWall     bool
+	Cost       int // The cost to go pass this square (in a maze context, it maybe a an obstacle or something)
+}
+
+// Node use for Graph algorithm
+type Node struct {
+	Index  int // This index is used for priority queue implementation, has nothing to do with the algorithm itself
+	Square Square
+	Parent *Node
+	Action Action
+	Cost   int // This cost is used for for calculation in the algorithm, it may change depend on which algo you use
+}
+
+// The Manhattan Distance, which simply is the sum of total columns and rows you need to go to read the destination
+func (node *Node) ManhattanDistance(dest Point) int {
+	return Abs(dest.Col-node.Square.Coordinate.Col) + Abs(dest.Row-node.Square.Coordinate.Row)
+}
+
+// The Eudiclian Distance
+func (node *Node) EuclidianDistance(dest Point) float64 {
+	col2 := math.Pow(float64(dest.Col-node.Square.Coordinate.Col), 2)
+	row2 := math.Pow(float64(dest.Row-node.Square.Coordinate.Row), 2)
+	return math.Sqrt(col2 + row2)
+}
+
+// Solution
+type Solution struct {
+	Actions []Action `json:"actions"`
+	Path    []Point  `json:"path"`
+}
+
+func (s *Solution) String() string {
+	var builder strings.Builder
+
+	// An empty path is either a start that is also the goal or no solution at all, which only the solve can tell
+	if len(s.Path) == 0 || len(s.Actions) == 0 {
+		return "No moves."
+	}
+
+	// Assume the first point in Path is reached after the first action
+	// The start point is not in Path, so we need the starting coordinate
+	// Since Path is built by backtracking from goal, we need the parent of the first Path point
+	// However, we don't have the start point directly, so we'll describe from the first move
+
+	for i := 0; i < len(s.Path); i++ {
+		action := s.Actions[i]
+		if action == NONE {
+			continue // Skip NONE action (initial state)
+		}
+		coord := s.Path[i]
+		if i == 0 {
+			// First step: imply starting from the previous point
+			fmt.Fprintf(&builder, "move %s to (%d, %d)", action, coord.Row, coord.Col)
+		} else {
+			// Subsequent steps
+			fmt.Fprintf(&builder, ", move %s to (%d, %d)", action, coord.Row, coord.Col)
+		}
+	}
+
+	// If no valid actions were found, return a message
+	if builder.Len() == 0 {
+		return "No valid moves in the solution."
+	}
+
+	return fmt.Sprintf("Start, %s, reach goal.", builder.String())
+}
+
+// Counters of the solver at one step
+type StepCounter struct {
+	Frontier  int // Number of nodes in the frontier
+	Explored  int // Number of nodes explored
+	Generated int // Number of squares of Trace.Enqueued added before the step, 0 when they aren't recorded
+}
+
+// Called by the solver on every step it takes, with the step index, the square it moved to and its counters
+type StepHook func(step int, p Point, counter StepCounter)
+
+// Maze struct. Solvers and renderers only read it, so one maze can be shared by solvers running at the same time.
+// The squares are read with Square, IsOpen and Cost, and a maze is created with Load, MazeBuilder or Generate
+type Maze struct {
+	Height   int
+	Width    int
+	Start    Point
+	Goal     Point
+	Topology Topology // How the solvers move between squares, nil means up, down, left and right only
+	Strict   bool     // Refuse rows of different lengths in Load instead of padding the short ones with walls
+	MaxCells int      // Refuse in Load a maze with more squares (height x width) than this, 0 means no limit
+
+	squares
+}
+
+// What a solver has recorded step by step while solving a maze
+type Trace struct {
+	ExperimentPath []Point       // The actual path that solver has taken, including incorrect path. Use solely for animation
+	Counters       []StepCounter // The counters at each step of ExperimentPath. Use solely for animation
+	Enqueued       []Point       // Every square added to the frontier, in order, the start included. Use solely for animation
+	SearchTree     []*Node       // Every node the solver has expanded, in expansion order. Use for exporting the search tree
+}
+
+// The numbers of a solve
+type Stats struct {
+	Expanded     int           `json:"nodes_expanded"`  // Nodes taken out of the frontier
+	Generated    int           `json:"nodes_generated"` // Nodes added to the frontier, the start node included
+	FrontierPeak int           `json:"frontier_peak"`   // The largest size the frontier has reached while solving
+	PathLength   int           `json:"path_length"`     // Number of moves of the solution
+	PathCost     int           `json:"path_cost"`       // Total cost of the solution path
+	SolveTime    time.Duration `json:"solve_time_ns"`   // How long the solver took to solve the maze
+	BytesAlloc   uint64        `json:"bytes_alloc"`     // Heap bytes allocated while solving, 0 if not measured. Counted for the whole process
+	Allocs       uint64        `json:"allocs"`          // Heap objects allocated while solving, 0 if not measured. Counted for the whole process
+}
+
+// What a solver returns. The maze itself is never modified, so one maze can be shared by many solvers
+type Result struct {
+	Solution    Solution // The solution, empty if the goal can't be reached
+	Explored    []Point  // Squares (more specifically, empty square), that we have visited
+	Unreachable bool     // The solver has explored every square it could reach without finding the goal
+	Trace
+	Stats
+}
+
+// A maze solved by one algorithm, which is what gets rendered and exported
+type Solved struct {
+	*Maze
+	SearchType Algo   // Which algorithm solved the maze
+	Heuristic  string // Name of the heuristic of GBFS and A*, empty for the other algorithms
+	Result            // What the solver has returned
+}
+
+// The solver has found a path to the goal. The path has no squares when the start is the goal
+func (s *Solved) Found() bool {
+	return len(s.Solution.Path) > 0 || s.Start == s.Goal
+}
+
+// Parse the string maze into Maze struct.
+// The structure should be a 2D array, where the start point is 'A', goal is 'B', wall is '#' and empty squares as empty (' ').
+// A byte order mark, CRLF line endings, tabs and trailing whitespace are tolerated, see mazeLines. Any other text gives
+// an ErrInvalidMaze locating the offending square
+func (m *Maze) Load(maze string) error {
+	lines, first := mazeLines(maze)
+	data := strings.Join(lines, "\n")
+	if !strings.Contains(data, "A") || !strings.Contains(data, "B") {
+		return ErrInvalidMaze{Row: -1, Col: -1, Reason: "need both starting and ending position for the maze"}
+	}
+
+	// Get the width and height of the maze. A row shorter than the others is padded with walls, unless the maze is strict
+	m.Height = len(lines)
+	m.Width = 0
+	for _, row := range lines {
+		m.Width = max(m.Width, len(row))
+	}
+	if m.MaxCells > 0 && m.Height*m.Width > m.MaxCells {
+		return ErrTooLarge{Height: m.Height, Width: m.Width, Limit: m.MaxCells}
+	}
+	if m.Strict {
+		for i, row := range lines {
+			if len(row) != m.Width {
+				return ErrInvalidMaze{Row: i, Col: min(len(row), m.Width), Reason: fmt.Sprintf("line %d is %d squares long instead of %d",
+					first+i+1, len(row), m.Width)}
+			}
+		}
+	}
+
+	// Get maze information (start, goal, squares coordinates). A maze has one start and one goal
+	m.squares = newSquares(m.Height, m.Width)
+	hasStart, hasGoal := false, false
+	for i, row := range lines {
+		for j := range m.Width {
+			p := Point{Row: i, Col: j}
+			if j >= len(row) {
+				m.setSquare(p, true, 0)
+				continue
+			}
+
+			// Check if the letter is valid
+			letter := row[j]
+			if letter != 'A' && letter != 'B' && letter != ' ' && letter != '#' && !('1' <= letter && letter <= '9') {
+				r, _ := utf8.DecodeRuneInString(row[j:])
+				return ErrInvalidMaze{Row: i, Col: j, Reason: fmt.Sprintf("invalid character %q", r)}
+			}
+
+			switch {
+			case letter == 'A':
+				if hasStart {
+					return ErrInvalidMaze{Row: i, Col: j, Reason: fmt.Sprintf("duplicate start (A), the first one is at row %d, column %d",
+						m.Start.Row, m.Start.Col)}
+				}
+				m.Start, hasStart = p, true
+				m.setSquare(p, false, 1)
+			case letter == 'B':
+				if hasGoal {
+					return ErrInvalidMaze{Row: i, Col: j, Reason: fmt.Sprintf("duplicate goal (B), the first one is at row %d, column %d",
+						m.Goal.Row, m.Goal.Col)}
+				}
+				m.Goal, hasGoal = p, true
+				m.setSquare(p, false, 1)
+			case letter == ' ' || letter == '1':
+				m.setSquare(p, false, 1)
+			case letter == '#':
+				m.setSquare(p, true, 0)
+			case '2' <= letter && letter <= '9':
+				m.setSquare(p, false, int(letter-'0'))
+			}
+		}
+	}
+
+	return nil
+}
+
+// The width of a tab in the text of a maze: a tab moves to the next multiple of it, like in a terminal
+const tabWidth = 8
+
+// Split the text of a maze into its rows, and get the index of the line of the first row. The text is decoded with
+// DecodeText, the line endings may be CRLF, tabs are expanded into spaces, the blank lines before and after the maze
+// are dropped, and so is the whitespace at the end of a row that would make it longer than every other row
+func mazeLines(text string) ([]string, int) {
+	lines := strings.Split(DecodeText(text), "\n")
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.Contains(line, "\t") {
+			var builder strings.Builder
+			for _, c := range line {
+				if c == '\t' {
+					builder.WriteString(strings.Repeat(" ", tabWidth-builder.Len()%tabWidth))
+					continue
+				}
+				builder.WriteRune(c)
+			}
+			line = builder.String()
+		}
+		lines[i] = line
+	}
+
+	first, last := 0, len(lines)
+	for first < last && strings.TrimSpace(lines[first]) == "" {
+		first++
+	}
+	for last > first && strings.TrimSpace(lines[last-1]) == "" {
+		last--
+	}
+	lines = lines[first:last]
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(strings.TrimRight(line, " ")))
+	}
+	for i, line := range lines {
+		if len(line) > width {
+			lines[i] = line[:width]
+		}
+	}
+
+	return lines, first
+}
+
+// The byte order marks a maze file may start with
+const (
+	bomUTF8    = "\xef\xbb\xbf"
+	bomUTF16LE = "\xff\xfe"
+	bomUTF16BE = "\xfe\xff"
+)
+
+// Decode the text of a maze file into UTF-8: a UTF-8 byte order mark is dropped, and a text starting with a UTF-16
+// one (e.g. saved as "Unicode" by Notepad) is converted. Any other text is returned as is
+func DecodeText(text string) string {
+	if rest, ok := strings.CutPrefix(text, bomUTF8); ok {
+		return rest
+	}
+
+	var bigEndian bool
+	switch {
+	case strings.HasPrefix(text, bomUTF16LE):
+	case strings.HasPrefix(text, bomUTF16BE):
+		bigEndian = true
+	default:
+		return text
+	}
+
+	units := make([]uint16, 0, len(text)/2-1)
+	for i := 2; i+1 < len(text); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(text[i])<<8|uint16(text[i+1]))
+		} else {
+			units = append(units, uint16(text[i+1])<<8|uint16(text[i]))
+		}
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// Get the maze in its text format. Start and goal take precedence over the cost of their square, a start that is also
+// the goal is written as A only, so the text can't be loaded back. Load drops the blank lines around a maze and the
+// trailing whitespace of its rows, so the first row, the last row and the last column are written with 1 instead of
+// spaces when they would be blank, to keep them
+func (maze *Maze) Rows() []string {
+	blank := func(p Point) bool {
+		sq := maze.Square(p)
+		return p != maze.Start && p != maze.Goal && !sq.IsWall && sq.Cost <= 1
+	}
+	blankRow := func(i int) bool {
+		for j := range maze.Width {
+			if !blank(Point{Row: i, Col: j}) {
+				return false
+			}
+		}
+		return true
+	}
+	blankCol := func(j int) bool {
+		for i := range maze.Height {
+			if !blank(Point{Row: i, Col: j}) {
+				return false
+			}
+		}
+		return true
+	}
+	top, bottom := maze.Height > 0 && blankRow(0), maze.Height > 0 && blankRow(maze.Height-1)
+	right := maze.Width > 0 && blankCol(maze.Width-1)
+
+	rows := make([]string, maze.Height)
+	for i := range maze.Height {
+		var builder strings.Builder
+		for j := range maze.Width {
+			sq := maze.Square(Point{Row: i, Col: j})
+			switch {
+			case sq.Coordinate == maze.Start:
+				builder.WriteByte('A')
+			case sq.Coordinate == maze.Goal:
+				builder.WriteByte('B')
+			case sq.IsWall:
+				builder.WriteByte('#')
+			case sq.Cost > 1:
+				builder.WriteByte(byte('0' + sq.Cost))
+			case (top && i == 0) || (bottom && i == maze.Height-1) || (right && j == maze.Width-1):
+				builder.WriteByte('1')
+			default:
+				builder.WriteByte(' ')
+			}
+		}
+		rows[i] = builder.String()
+	}
+
+	return rows
+}
+
+// Record a step the solver has taken, together with the current frontier size, and report it to hook if not nil.
+// A step to the square of the last step only updates its counters, and isn't reported
+func (r *Result) RecordStep(p Point, frontier int, hook StepHook) {
+	counter := StepCounter{Frontier: frontier, Explored: len(r.Explored), Generated: len(r.Enqueued)}
+	if n := len(r.ExperimentPath); n > 0 && n == len(r.Counters) && r.ExperimentPath[n-1] == p {
+		r.Counters[n-1] = counter
+		return
+	}
+
+	r.ExperimentPath = append(r.ExperimentPath, p)
+	r.Counters = append(r.Counters, counter)
+
+	if hook != nil {
+		hook(len(r.ExperimentPath)-1, p, r.Counters[len(r.Counters)-1])
+	}
+}
+
+// Get the total cost of a path, which is the sum of the cost of every square on the path
+// (the start square is not on a solution path, so it's not counted)
+func (maze *Maze) CostOf(path []Point) int {
+	cost := 0
+	for _, p := range path {
+		cost += maze.Cost(p)
+	}
+
+	return cost
+}
+
+func Abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
@@ -0,0 +1,80 @@
+package maze
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Load never panics, refuses bad text with an ErrInvalidMaze or ErrTooLarge, and a loaded maze has open ends and is
+// written back by Rows into the same maze
+func FuzzLoad(f *testing.F) {
+	for _, seed := range []string{
+		"A B",
+		"A#\n B",
+		"\ufeffA 5\r\n#9B\r\n",
+		"\tA\n\n B  \n",
+		"A\nB#\n",
+		"AA B",
+		"A x B",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		m := &Maze{MaxCells: 1 << 16}
+		if err := m.Load(text); err != nil {
+			var invalid ErrInvalidMaze
+			var tooLarge ErrTooLarge
+			if !errors.As(err, &invalid) && !errors.As(err, &tooLarge) {
+				t.Fatalf("unexpected error type %T: %v", err, err)
+			}
+			return
+		}
+
+		if !m.IsOpen(m.Start) || !m.IsOpen(m.Goal) {
+			t.Fatalf("start %v or goal %v is not an open square", m.Start, m.Goal)
+		}
+		if m.Start == m.Goal {
+			t.Fatalf("start and goal are the same square %v", m.Start)
+		}
+
+		rows := m.Rows()
+		again := &Maze{}
+		if err := again.Load(strings.Join(rows, "\n")); err != nil {
+			t.Fatalf("the rows of a loaded maze can't be loaded back: %v\n%s", err, strings.Join(rows, "\n"))
+		}
+		if !slices.Equal(rows, again.Rows()) {
+			t.Fatalf("the maze has changed when loaded back:\n%s\ninstead of\n%s", strings.Join(again.Rows(), "\n"),
+				strings.Join(rows, "\n"))
+		}
+	})
+}
+
+// Load a generated 1001x1001 weighted maze, reporting the bytes allocated per square with the walls as bits and the
+// costs as bytes. Run with go test -bench Load ./maze
+func BenchmarkLoad(b *testing.B) {
+	generated, err := Generate(1001, 1001, GenerateOptions{Seed: 1, Loops: 0.1, Weighted: true})
+	if err != nil {
+		b.Fatal(err)
+	}
+	text := strings.Join(generated.Rows(), "\n")
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		m := &Maze{}
+		if err := m.Load(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+
+	squares := float64(b.N) * float64(generated.Height*generated.Width)
+	b.ReportMetric(float64(after.TotalAlloc-before.TotalAlloc)/squares, "B/square")
+}
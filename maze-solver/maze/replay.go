@@ -0,0 +1,198 @@
+package maze
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Version of the replay format, bumped on incompatible changes
+const replayVersion = 1
+
+// The replay file: a gzipped JSON document with everything needed to render the solving again.
+// Points and steps are stored as plain int arrays to keep the file small
+type replay struct {
+	Version      int          `json:"version"`
+	Maze         []string     `json:"maze"` // The maze in its text format, one row per line
+	Algorithm    Algo         `json:"algorithm"`
+	Heuristic    string       `json:"heuristic,omitempty"`
+	SolveTime    int64        `json:"solve_time_ns"`
+	FrontierPeak int          `json:"frontier_peak"`
+	Generated    int          `json:"nodes_generated,omitempty"`
+	BytesAlloc   uint64       `json:"bytes_alloc,omitempty"`
+	Allocs       uint64       `json:"allocs,omitempty"`
+	Steps        [][5]int     `json:"steps"`              // row, col, frontier size, explored count, enqueued count of each step
+	Enqueued     [][2]int     `json:"enqueued,omitempty"` // row, col
+	Explored     [][2]int     `json:"explored"`           // row, col
+	Tree         []replayNode `json:"tree"`
+	Solution     Solution     `json:"solution"`
+	Unreachable  bool         `json:"unreachable,omitempty"`
+}
+
+// A node of the search tree, its parent is referenced by expansion order (-1 for none)
+type replayNode struct {
+	Row    int    `json:"r"`
+	Col    int    `json:"c"`
+	Parent int    `json:"p"`
+	Action Action `json:"a"`
+	Cost   int    `json:"g"`
+}
+
+// Save the solver trace of a solved maze (expansions, frontier sizes, search tree and final path), so it can be
+// rendered again later with different render options without solving it again
+func SaveReplay(w io.Writer, m *Solved) error {
+	r := replay{
+		Version:      replayVersion,
+		Maze:         m.Rows(),
+		Algorithm:    m.SearchType,
+		Heuristic:    m.Heuristic,
+		SolveTime:    int64(m.SolveTime),
+		FrontierPeak: m.FrontierPeak,
+		Generated:    m.Generated,
+		BytesAlloc:   m.BytesAlloc,
+		Allocs:       m.Allocs,
+		Solution:     m.Solution,
+		Unreachable:  m.Unreachable,
+	}
+
+	for i, p := range m.ExperimentPath {
+		var counter StepCounter
+		if i < len(m.Counters) {
+			counter = m.Counters[i]
+		}
+		r.Steps = append(r.Steps, [5]int{p.Row, p.Col, counter.Frontier, counter.Explored, counter.Generated})
+	}
+
+	for _, p := range m.Enqueued {
+		r.Enqueued = append(r.Enqueued, [2]int{p.Row, p.Col})
+	}
+
+	for _, p := range m.Explored {
+		r.Explored = append(r.Explored, [2]int{p.Row, p.Col})
+	}
+
+	order := make(map[*Node]int)
+	for i, node := range m.SearchTree {
+		order[node] = i
+	}
+	for i, node := range m.SearchTree {
+		parent, ok := order[node.Parent]
+		if !ok || parent >= i {
+			parent = -1
+		}
+
+		r.Tree = append(r.Tree, replayNode{
+			Row:    node.Square.Coordinate.Row,
+			Col:    node.Square.Coordinate.Col,
+			Parent: parent,
+			Action: node.Action,
+			Cost:   node.Cost,
+		})
+	}
+
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(r); err != nil {
+		zw.Close()
+		return fmt.Errorf("failed to encode replay: %v", err)
+	}
+
+	return zw.Close()
+}
+
+// Load a replay saved by SaveReplay back into a solved maze. A replay whose trace or solution doesn't fit its maze is
+// refused, so the renderers can trust what they draw
+func LoadReplay(rd io.Reader) (*Solved, error) {
+	zr, err := gzip.NewReader(rd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay: %v", err)
+	}
+	defer zr.Close()
+
+	var r replay
+	if err := json.NewDecoder(zr).Decode(&r); err != nil {
+		return nil, fmt.Errorf("failed to decode replay: %v", err)
+	}
+
+	if r.Version != replayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", r.Version)
+	}
+
+	m := &Solved{Maze: &Maze{}, SearchType: r.Algorithm, Heuristic: r.Heuristic}
+	if err := m.Load(strings.Join(r.Maze, "\n")); err != nil {
+		return nil, err
+	}
+
+	inside := func(row, col int) bool {
+		return m.Inside(Point{Row: row, Col: col})
+	}
+
+	m.SolveTime = time.Duration(r.SolveTime)
+	m.FrontierPeak = r.FrontierPeak
+	m.Generated = r.Generated
+	m.BytesAlloc = r.BytesAlloc
+	m.Allocs = r.Allocs
+	m.Solution = r.Solution
+	m.Unreachable = r.Unreachable
+
+	for _, p := range r.Enqueued {
+		if !inside(p[0], p[1]) {
+			return nil, fmt.Errorf("replay enqueued square (%d, %d) is outside of the maze", p[0], p[1])
+		}
+		m.Enqueued = append(m.Enqueued, Point{Row: p[0], Col: p[1]})
+	}
+
+	// The replays saved before the enqueued squares were recorded have 4 numbers per step, the last one is 0
+	generated := 0
+	for _, step := range r.Steps {
+		if !inside(step[0], step[1]) {
+			return nil, fmt.Errorf("replay step (%d, %d) is outside of the maze", step[0], step[1])
+		}
+		if step[4] < generated || step[4] > len(m.Enqueued) {
+			return nil, fmt.Errorf("replay step (%d, %d) has %d enqueued squares, expected between %d and %d", step[0],
+				step[1], step[4], generated, len(m.Enqueued))
+		}
+		generated = step[4]
+		m.ExperimentPath = append(m.ExperimentPath, Point{Row: step[0], Col: step[1]})
+		m.Counters = append(m.Counters, StepCounter{Frontier: step[2], Explored: step[3], Generated: step[4]})
+	}
+
+	for _, p := range r.Explored {
+		if !inside(p[0], p[1]) {
+			return nil, fmt.Errorf("replay explored square (%d, %d) is outside of the maze", p[0], p[1])
+		}
+		m.Explored = append(m.Explored, Point{Row: p[0], Col: p[1]})
+	}
+
+	for i, n := range r.Tree {
+		if !inside(n.Row, n.Col) {
+			return nil, fmt.Errorf("replay node (%d, %d) is outside of the maze", n.Row, n.Col)
+		}
+		if n.Parent >= i {
+			return nil, fmt.Errorf("replay node %d has parent %d, which is not expanded before it", i, n.Parent)
+		}
+
+		node := &Node{Square: m.Square(Point{Row: n.Row, Col: n.Col}), Action: n.Action, Cost: n.Cost}
+		if n.Parent >= 0 {
+			node.Parent = m.SearchTree[n.Parent]
+		}
+		m.SearchTree = append(m.SearchTree, node)
+	}
+
+	if len(m.Solution.Actions) != len(m.Solution.Path) {
+		return nil, fmt.Errorf("replay solution has %d actions but %d squares", len(m.Solution.Actions), len(m.Solution.Path))
+	}
+	for _, p := range m.Solution.Path {
+		if !m.IsOpen(p) {
+			return nil, fmt.Errorf("replay path square (%d, %d) is outside of the maze or a wall", p.Row, p.Col)
+		}
+	}
+
+	// The other stats can be found again from the trace
+	m.Expanded = len(m.SearchTree)
+	m.PathLength = len(m.Solution.Path)
+	m.PathCost = m.CostOf(m.Solution.Path)
+	return m, nil
+}
@@ -0,0 +1,61 @@
+package maze_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"slices"
+	"testing"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+)
+
+// LoadReplay never panics on the JSON of a replay, and a replay it loads can be rendered and saved into the same
+// replay. The seed corpus under testdata holds the replays of a solved, a weighted and an unsolvable maze
+func FuzzLoadReplay(f *testing.F) {
+	f.Add([]byte(`{"version":1,"maze":["AB"],"algorithm":"bfs","steps":[[0,0,1,0,1],[0,1,1,1,2]],` +
+		`"enqueued":[[0,0],[0,1]],"explored":[[0,0],[0,1]],"tree":[{"r":0,"c":0,"p":-1,"a":"none","g":1},` +
+		`{"r":0,"c":1,"p":0,"a":"right","g":2}],"solution":{"actions":["right"],"path":[{"row":0,"col":1}]}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		solved, err := maze.LoadReplay(bytes.NewReader(gzipped(t, data)))
+		if err != nil {
+			return
+		}
+
+		opts := render.DefaultRenderOptions()
+		opts.CellSize = 4
+		if _, err := render.CreateSolutionImage(solved, opts); err != nil {
+			t.Fatalf("a loaded replay can't be rendered: %v", err)
+		}
+
+		var saved bytes.Buffer
+		if err := maze.SaveReplay(&saved, solved); err != nil {
+			t.Fatalf("a loaded replay can't be saved: %v", err)
+		}
+		again, err := maze.LoadReplay(&saved)
+		if err != nil {
+			t.Fatalf("a saved replay can't be loaded back: %v", err)
+		}
+		if !slices.Equal(solved.Rows(), again.Rows()) || !slices.Equal(solved.Solution.Path, again.Solution.Path) ||
+			!slices.Equal(solved.ExperimentPath, again.ExperimentPath) || len(solved.SearchTree) != len(again.SearchTree) {
+			t.Fatal("the replay has changed once saved and loaded back")
+		}
+	})
+}
+
+// Compress data like a replay file is
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
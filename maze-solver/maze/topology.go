@@ -0,0 +1,121 @@
+package maze
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// How a solver can move in the maze: which squares are the neighbors of a square, and the action to reach each of them.
+// Set it on the maze to change the movement without touching the solvers
+type Topology interface {
+	// Append the open squares reached by one move from p to buf, always in the same order, and return the extended buf.
+	// The solvers give back the same buf on every call, so listing the neighbors allocates nothing once it's large enough
+	AppendNeighbors(m *Maze, p Point, buf []Neighbor) []Neighbor
+}
+
+// An open square reached by one move of a topology, and the action of the move
+type Neighbor struct {
+	Point
+	Action Action
+}
+
+// Move up, down, left and right. This is the default topology
+type FourWay struct{}
+
+// Move up, down, left, right and diagonally. A diagonal move can't cut the corner of a wall
+type EightWay struct{}
+
+// One move of a topology
+type move struct {
+	Row, Col int
+	Action   Action
+}
+
+// In order: left, top, right, bottom
+var fourWayMoves = []move{
+	{0, -1, LEFT},
+	{-1, 0, UP},
+	{0, 1, RIGHT},
+	{1, 0, DOWN},
+}
+
+var diagonalMoves = []move{
+	{-1, -1, UP_LEFT},
+	{-1, 1, UP_RIGHT},
+	{1, 1, DOWN_RIGHT},
+	{1, -1, DOWN_LEFT},
+}
+
+// The four-way moves first, then the diagonal ones
+var eightWayMoves = append(append([]move{}, fourWayMoves...), diagonalMoves...)
+
+func (FourWay) AppendNeighbors(m *Maze, p Point, buf []Neighbor) []Neighbor {
+	return m.appendMoves(buf, p, fourWayMoves)
+}
+
+func (EightWay) AppendNeighbors(m *Maze, p Point, buf []Neighbor) []Neighbor {
+	return m.appendMoves(buf, p, eightWayMoves)
+}
+
+var topologies = map[string]Topology{
+	"four":  FourWay{},
+	"eight": EightWay{},
+}
+
+// Get the names of the built-in topologies, sorted
+func TopologyNames() []string {
+	return slices.Sorted(maps.Keys(topologies))
+}
+
+// Get a built-in topology by its name: four or eight
+func TopologyByName(name string) (Topology, error) {
+	topology, ok := topologies[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown movement %q, supported: four, eight", name)
+	}
+
+	return topology, nil
+}
+
+// Append the neighbors of the square p with the topology of the maze to buf, and return the extended buf
+func (maze *Maze) AppendNeighbors(p Point, buf []Neighbor) []Neighbor {
+	if maze.Topology == nil {
+		return FourWay{}.AppendNeighbors(maze, p, buf)
+	}
+
+	return maze.Topology.AppendNeighbors(maze, p, buf)
+}
+
+// Get the nodes of the neighbors of the node with the topology of the maze, node being their parent. The nodes are
+// allocated together, use AppendNeighbors where it's called for every square
+func (maze *Maze) Neighbors(node *Node) []*Node {
+	neighbors := maze.AppendNeighbors(node.Square.Coordinate, nil)
+	nodes := make([]Node, len(neighbors))
+	children := make([]*Node, len(neighbors))
+	for i, next := range neighbors {
+		nodes[i] = Node{Square: maze.Square(next.Point), Action: next.Action, Parent: node}
+		children[i] = &nodes[i]
+	}
+
+	return children
+}
+
+// Append the open squares reached by the moves from p to buf. A diagonal move can't cut the corner of a wall: both
+// squares next to the corner need to be open
+func (maze *Maze) appendMoves(buf []Neighbor, p Point, moves []move) []Neighbor {
+	for _, mv := range moves {
+		next := Point{Row: p.Row + mv.Row, Col: p.Col + mv.Col}
+		if !maze.IsOpen(next) {
+			continue
+		}
+		if mv.Row != 0 && mv.Col != 0 && (!maze.IsOpen(Point{Row: next.Row, Col: p.Col}) || !maze.IsOpen(Point{Row: p.Row, Col: next.Col})) {
+			continue
+		}
+
+		buf = append(buf, Neighbor{Point: next, Action: mv.Action})
+	}
+
+	return buf
+}
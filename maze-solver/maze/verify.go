@@ -0,0 +1,82 @@
+package maze
+
+import "fmt"
+
+// The row and column change of every action
+var actionMoves = func() map[Action]move {
+	moves := map[Action]move{}
+	for _, mv := range eightWayMoves {
+		moves[mv.Action] = mv
+	}
+
+	return moves
+}()
+
+// Check that solution is a valid path of m: every square is inside the maze and not a wall, each square is next to
+// the previous one (the first one next to the start), the actions match the moves, every move is allowed by the
+// topology of the maze, and the path ends at the goal
+func VerifySolution(m *Maze, solution Solution) error {
+	if len(solution.Actions) != len(solution.Path) {
+		return fmt.Errorf("the solution has %d actions but %d squares", len(solution.Actions), len(solution.Path))
+	}
+
+	prev := &Node{Square: m.Square(m.Start)}
+	for i, p := range solution.Path {
+		if !m.Inside(p) {
+			return fmt.Errorf("step %d: square (%d, %d) is outside of the maze", i, p.Row, p.Col)
+		}
+
+		if !m.IsOpen(p) {
+			return fmt.Errorf("step %d: square (%d, %d) is a wall", i, p.Row, p.Col)
+		}
+
+		from := prev.Square.Coordinate
+		dRow, dCol := p.Row-from.Row, p.Col-from.Col
+		if Abs(dRow) > 1 || Abs(dCol) > 1 || (dRow == 0 && dCol == 0) {
+			return fmt.Errorf("step %d: square (%d, %d) is not next to (%d, %d)", i, p.Row, p.Col, from.Row, from.Col)
+		}
+
+		action := solution.Actions[i]
+		if mv, ok := actionMoves[action]; !ok || mv.Row != dRow || mv.Col != dCol {
+			return fmt.Errorf("step %d: action %q does not move from (%d, %d) to (%d, %d)", i, action, from.Row, from.Col, p.Row, p.Col)
+		}
+
+		// The move must also be one the solvers can make, e.g. no diagonal move on a four-way maze
+		var next *Node
+		for _, neighbor := range m.Neighbors(prev) {
+			if neighbor.Square.Coordinate == p && neighbor.Action == action {
+				next = neighbor
+				break
+			}
+		}
+		if next == nil {
+			return fmt.Errorf("step %d: moving %s from (%d, %d) is not allowed by the maze topology", i, action, from.Row, from.Col)
+		}
+
+		prev = next
+	}
+
+	if end := prev.Square.Coordinate; end != m.Goal {
+		return fmt.Errorf("the solution ends at (%d, %d) instead of the goal (%d, %d)", end.Row, end.Col, m.Goal.Row, m.Goal.Col)
+	}
+
+	return nil
+}
+
+// Check that the solution of result is a valid path of m with VerifySolution, and that the length and cost reported in
+// its stats are the ones of the path
+func VerifyResult(m *Maze, result Result) error {
+	if err := VerifySolution(m, result.Solution); err != nil {
+		return err
+	}
+
+	if length := len(result.Solution.Path); result.PathLength != length {
+		return fmt.Errorf("path length %d reported for a path of %d squares", result.PathLength, length)
+	}
+
+	if cost := m.CostOf(result.Solution.Path); result.PathCost != cost {
+		return fmt.Errorf("path cost %d reported for a path costing %d", result.PathCost, cost)
+	}
+
+	return nil
+}
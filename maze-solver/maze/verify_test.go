@@ -0,0 +1,114 @@
+package maze
+
+import "testing"
+
+// A four-way maze with a weighted square in the middle, whose shortest path is right, down, down, right
+const verifyMaze = "A #\n 3 \n# B"
+
+func loadVerifyMaze(t *testing.T) *Maze {
+	t.Helper()
+
+	m := &Maze{}
+	if err := m.Load(verifyMaze); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+// VerifySolution accepts the path from the start to the goal, and refuses a path through a wall, a step that skips a
+// square, a move the topology doesn't allow, an action that doesn't match its move, and a path that doesn't start
+// next to the start or doesn't end at the goal
+func TestVerifySolution(t *testing.T) {
+	m := loadVerifyMaze(t)
+
+	tests := []struct {
+		name     string
+		solution Solution
+		valid    bool
+	}{
+		{"valid", Solution{
+			Actions: []Action{RIGHT, DOWN, DOWN, RIGHT},
+			Path:    []Point{{0, 1}, {1, 1}, {2, 1}, {2, 2}},
+		}, true},
+		{"through a wall", Solution{
+			Actions: []Action{RIGHT, RIGHT, DOWN, DOWN},
+			Path:    []Point{{0, 1}, {0, 2}, {1, 2}, {2, 2}},
+		}, false},
+		{"non-adjacent step", Solution{
+			Actions: []Action{RIGHT, DOWN, RIGHT},
+			Path:    []Point{{0, 1}, {2, 1}, {2, 2}},
+		}, false},
+		{"diagonal on four-way", Solution{
+			Actions: []Action{RIGHT, DOWN, DOWN_RIGHT},
+			Path:    []Point{{0, 1}, {1, 1}, {2, 2}},
+		}, false},
+		{"wrong action", Solution{
+			Actions: []Action{RIGHT, DOWN, DOWN, LEFT},
+			Path:    []Point{{0, 1}, {1, 1}, {2, 1}, {2, 2}},
+		}, false},
+		{"wrong start", Solution{
+			Actions: []Action{DOWN, RIGHT},
+			Path:    []Point{{2, 1}, {2, 2}},
+		}, false},
+		{"wrong goal", Solution{
+			Actions: []Action{RIGHT, DOWN, DOWN},
+			Path:    []Point{{0, 1}, {1, 1}, {2, 1}},
+		}, false},
+		{"outside of the maze", Solution{
+			Actions: []Action{UP},
+			Path:    []Point{{-1, 0}},
+		}, false},
+		{"more actions than squares", Solution{
+			Actions: []Action{RIGHT, DOWN, DOWN, RIGHT, RIGHT},
+			Path:    []Point{{0, 1}, {1, 1}, {2, 1}, {2, 2}},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySolution(m, tt.solution)
+			if tt.valid && err != nil {
+				t.Errorf("valid solution refused: %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("invalid solution accepted")
+			}
+		})
+	}
+}
+
+// VerifyResult refuses a valid path whose reported length or cost isn't the one of the path
+func TestVerifyResult(t *testing.T) {
+	m := loadVerifyMaze(t)
+
+	solution := Solution{
+		Actions: []Action{RIGHT, DOWN, DOWN, RIGHT},
+		Path:    []Point{{0, 1}, {1, 1}, {2, 1}, {2, 2}},
+	}
+	length, cost := len(solution.Path), m.CostOf(solution.Path)
+
+	tests := []struct {
+		name         string
+		length, cost int
+		valid        bool
+	}{
+		{"valid", length, cost, true},
+		{"wrong cost", length, cost - 1, false},
+		{"unweighted cost", length, length, false},
+		{"wrong length", length + 1, cost, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Result{Solution: solution, Stats: Stats{PathLength: tt.length, PathCost: tt.cost}}
+			err := VerifyResult(m, result)
+			if tt.valid && err != nil {
+				t.Errorf("valid result refused: %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("invalid result accepted")
+			}
+		})
+	}
+}
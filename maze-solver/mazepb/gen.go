@@ -0,0 +1,6 @@
+// Package mazepb holds the protobuf messages and the gRPC service of the serve command, generated from
+// maze_solver.proto. Run go generate in this directory after changing it, protoc, protoc-gen-go and protoc-gen-go-grpc
+// are needed
+package mazepb
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative mazepb/maze_solver.proto
@@ -0,0 +1,236 @@
+// Package mazetest helps property-testing maze solvers: it generates random mazes and checks the invariants every
+// solver has to keep on them, so a custom solver can be tested the same way as the solvers of this module.
+//
+// A property is checked on many random mazes with Check, for example in a test:
+//
+//	err := mazetest.Check(1, 200, mazetest.Options{MaxLoops: 0.3, Weighted: true},
+//		mazetest.ValidPath(mySolver), mazetest.Optimal(mySolver))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+package mazetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// Options of the random mazes
+type Options struct {
+	MinSize    int           // Fewest rows and columns of a maze, 5 when 0
+	MaxSize    int           // Most rows and columns of a maze, 31 when 0
+	MaxLoops   float64       // Each maze opens a random fraction of its inner walls, up to this one
+	Weighted   bool          // Give the open squares a random cost from 1 to 9
+	RandomEnds bool          // Put the start and the goal on random open squares instead of the opposite corners
+	SameEnds   float64       // Fraction of the mazes whose goal is their start, which are solved without moving
+	Unsolvable float64       // Fraction of the mazes whose goal is walled off
+	Topology   maze.Topology // How the solvers move in the mazes, nil means up, down, left and right only
+}
+
+// Create the solver to check on a maze
+type Factory func(m *maze.Maze) (solve.Solver, error)
+
+// An invariant of the solvers on a maze, which returns nil when it holds
+type Property func(m *maze.Maze) error
+
+// Get the factory of a solver of this module. h is the heuristic of GBFS and A*, nil means the default of each algorithm
+func Algo(algo maze.Algo, h solve.Heuristic) Factory {
+	return func(m *maze.Maze) (solve.Solver, error) {
+		return solve.NewSolverForAlgo(algo, m, h)
+	}
+}
+
+// Generate the random maze of seed. The same seed and options always give the same maze
+func RandomMaze(seed int64, opts Options) (*maze.Maze, error) {
+	minSize, maxSize := opts.MinSize, opts.MaxSize
+	if minSize == 0 {
+		minSize = 5
+	}
+	if maxSize == 0 {
+		maxSize = max(31, minSize)
+	}
+	if minSize < 3 || maxSize < minSize {
+		return nil, fmt.Errorf("invalid maze sizes from %d to %d, they need to be at least 3", minSize, maxSize)
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	height, width := minSize+r.Intn(maxSize-minSize+1), minSize+r.Intn(maxSize-minSize+1)
+	generated, err := maze.Generate(height, width, maze.GenerateOptions{
+		Seed:     r.Int63(),
+		Loops:    opts.MaxLoops * r.Float64(),
+		Weighted: opts.Weighted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	generated.Topology = opts.Topology
+	if !opts.RandomEnds && opts.SameEnds == 0 && r.Float64() >= opts.Unsolvable {
+		return generated, nil
+	}
+
+	// Copy the maze to move its start and goal, or wall its goal off
+	var open []maze.Point
+	b := maze.NewMazeBuilder(generated.Height, generated.Width)
+	for i := range generated.Height {
+		for j := range generated.Width {
+			p := maze.Point{Row: i, Col: j}
+			if !generated.IsOpen(p) {
+				b.SetWall(p)
+				continue
+			}
+			b.SetCost(p, generated.Cost(p))
+			open = append(open, p)
+		}
+	}
+
+	start, goal := generated.Start, generated.Goal
+	if opts.RandomEnds && len(open) > 1 {
+		picked := r.Perm(len(open))
+		start, goal = open[picked[0]], open[picked[1]]
+	}
+	if opts.SameEnds > 0 && r.Float64() < opts.SameEnds {
+		goal = start
+	}
+	if r.Float64() < opts.Unsolvable {
+		for _, p := range open {
+			if max(maze.Abs(p.Row-goal.Row), maze.Abs(p.Col-goal.Col)) == 1 && p != start {
+				b.SetWall(p)
+			}
+		}
+	}
+
+	m, err := b.SetStart(start).SetGoal(goal).Build()
+	if err != nil {
+		return nil, err
+	}
+	m.Topology = opts.Topology
+	return m, nil
+}
+
+// Check every property on n random mazes, made from the seeds seed to seed+n-1. The first property that fails is
+// returned as an error with the seed of its maze and the maze itself, so it can be reproduced with RandomMaze
+func Check(seed int64, n int, opts Options, props ...Property) error {
+	for i := range int64(n) {
+		m, err := RandomMaze(seed+i, opts)
+		if err != nil {
+			return fmt.Errorf("seed %d: %v", seed+i, err)
+		}
+
+		for _, prop := range props {
+			if err := prop(m); err != nil {
+				return fmt.Errorf("seed %d: %v\n%s", seed+i, err, strings.Join(m.Rows(), "\n"))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Solve m with the solver of factory
+func solveWith(factory Factory, m *maze.Maze) (maze.Result, error) {
+	solver, err := factory(m)
+	if err != nil {
+		return maze.Result{}, err
+	}
+
+	return solver.SolveContext(context.Background())
+}
+
+// The solver finds a valid path from the start to the goal whenever the goal can be reached, returns
+// solve.ErrNoSolution when it can't, and reports the length and the cost of its path
+func ValidPath(factory Factory) Property {
+	return func(m *maze.Maze) error {
+		result, err := solveWith(factory, m)
+		reachable := m.Reachable(m.Start, m.Goal)
+		switch {
+		case errors.Is(err, solve.ErrNoSolution) && reachable:
+			return errors.New("no solution found, but the goal can be reached")
+		case errors.Is(err, solve.ErrNoSolution):
+			return nil
+		case err != nil:
+			return err
+		case !reachable:
+			return errors.New("a solution is found, but the goal can't be reached")
+		}
+
+		if err := maze.VerifyResult(m, result); err != nil {
+			return fmt.Errorf("invalid solution: %v", err)
+		}
+
+		return nil
+	}
+}
+
+// The path of the solver costs as little as the one of BFS on unweighted mazes and Dijkstra on weighted ones
+func Optimal(factory Factory) Property {
+	return func(m *maze.Maze) error {
+		optimal, reference, err := solve.OptimalCost(context.Background(), m)
+		if errors.Is(err, solve.ErrNoSolution) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("%s: %v", reference, err)
+		}
+
+		result, err := solveWith(factory, m)
+		if err != nil {
+			return err
+		}
+		if result.PathCost != optimal {
+			return fmt.Errorf("path cost %d instead of the optimal %d of %s", result.PathCost, optimal, reference)
+		}
+
+		return nil
+	}
+}
+
+// The path of BFS has no more moves than the one of DFS
+func BFSNotLongerThanDFS() Property {
+	return func(m *maze.Maze) error {
+		bfs, err := solveWith(Algo(maze.BFS, nil), m)
+		if errors.Is(err, solve.ErrNoSolution) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("bfs: %v", err)
+		}
+
+		dfs, err := solveWith(Algo(maze.DFS, nil), m)
+		if err != nil {
+			return fmt.Errorf("dfs: %v", err)
+		}
+		if bfs.PathLength > dfs.PathLength {
+			return fmt.Errorf("bfs path of %d moves is longer than the dfs path of %d", bfs.PathLength, dfs.PathLength)
+		}
+
+		return nil
+	}
+}
+
+// The path of A* costs the same as the one of Dijkstra. h has to be admissible with the topology of the mazes (e.g.
+// euclidean or manhattan with four-way moves, chebyshev with eight-way moves), nil means the default of A*, which is
+// admissible with both
+func AStarMatchesDijkstra(h solve.Heuristic) Property {
+	return func(m *maze.Maze) error {
+		dijkstra, err := solveWith(Algo(maze.DIJKSTRA, nil), m)
+		if errors.Is(err, solve.ErrNoSolution) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("dijkstra: %v", err)
+		}
+
+		astar, err := solveWith(Algo(maze.ASTAR, h), m)
+		if err != nil {
+			return fmt.Errorf("astar: %v", err)
+		}
+		if astar.PathCost != dijkstra.PathCost {
+			return fmt.Errorf("astar path costs %d, dijkstra path costs %d", astar.PathCost, dijkstra.PathCost)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// The upper bounds of the buckets of the histograms of the server metrics
+var (
+	secondBuckets   = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30}
+	expandedBuckets = []float64{10, 100, 1_000, 10_000, 100_000, 1_000_000, 10_000_000}
+)
+
+// The reasons a request of the server fails, the reason label of maze_server_errors_total
+const (
+	reasonBadRequest      = "bad_request"      // Invalid parameters or maze
+	reasonTooLarge        = "too_large"        // The maze is larger than -max-bytes
+	reasonTimeout         = "timeout"          // The solve took longer than -timeout
+	reasonCancelled       = "cancelled"        // The client went away before the end of the solve
+	reasonBusy            = "busy"             // The job queue is full
+	reasonUnauthorized    = "unauthorized"     // Invalid or missing API key
+	reasonRateLimited     = "rate_limited"     // The client has sent too many requests
+	reasonForbiddenOrigin = "forbidden_origin" // A WebSocket opened by a web page of an origin that isn't allowed
+	reasonInternal        = "internal"
+)
+
+// Observations of a histogram, counted in buckets
+type histogram struct {
+	bounds []float64
+	counts []uint64 // The observations of each bucket, not cumulated
+	sum    float64
+	count  uint64
+}
+
+// Add an observation
+func (h *histogram) observe(v float64) {
+	if i, _ := slices.BinarySearch(h.bounds, v); i < len(h.bounds) {
+		h.counts[i]++
+	}
+	h.sum += v
+	h.count++
+}
+
+// The metrics of the serve command, written in the text format of Prometheus by /metrics. The labels of a series are
+// kept in their text form, e.g. `algo="bfs",api="http"`
+type serverMetrics struct {
+	mu       sync.Mutex
+	solves   map[string]uint64     // maze_server_solves_total, by api, algo and outcome
+	errors   map[string]uint64     // maze_server_errors_total, by api and reason
+	duration map[string]*histogram // maze_server_solve_duration_seconds, by algo
+	expanded map[string]*histogram // maze_server_nodes_expanded, by algo
+	render   map[string]*histogram // maze_server_render_duration_seconds, by format
+}
+
+// Create empty metrics
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		solves:   map[string]uint64{},
+		errors:   map[string]uint64{},
+		duration: map[string]*histogram{},
+		expanded: map[string]*histogram{},
+		render:   map[string]*histogram{},
+	}
+}
+
+// Get the text form of labels given as name and value pairs
+func metricLabels(pairs ...string) string {
+	labels := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, pairs[i]+"="+strconv.Quote(pairs[i+1]))
+	}
+
+	return strings.Join(labels, ",")
+}
+
+// Get the histogram of labels in histograms, created with bounds if missing
+func histogramOf(histograms map[string]*histogram, labels string, bounds []float64) *histogram {
+	h, ok := histograms[labels]
+	if !ok {
+		h = &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+		histograms[labels] = h
+	}
+
+	return h
+}
+
+// Count a request of api that has failed before solving, for reason
+func (sm *serverMetrics) failed(api, reason string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.errors[metricLabels("api", api, "reason", reason)]++
+}
+
+// Count a solve of algo through api, from the result and error of the solver. A solve that has failed only counts as an
+// error
+func (sm *serverMetrics) solved(api string, algo maze.Algo, result maze.Result, err error) {
+	var invalid maze.ErrInvalidMaze
+	var cost maze.ErrUnsupportedCost
+	switch {
+	case errors.As(err, &invalid), errors.As(err, &cost):
+		sm.failed(api, reasonBadRequest)
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		sm.failed(api, reasonTimeout)
+		return
+	case errors.Is(err, context.Canceled):
+		sm.failed(api, reasonCancelled)
+		return
+	case err != nil && !errors.Is(err, solve.ErrNoSolution):
+		sm.failed(api, reasonInternal)
+		return
+	}
+
+	outcome := "solved"
+	if err != nil {
+		outcome = "no_solution"
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.solves[metricLabels("algo", string(algo), "api", api, "outcome", outcome)]++
+	histogramOf(sm.duration, metricLabels("algo", string(algo)), secondBuckets).observe(result.SolveTime.Seconds())
+	histogramOf(sm.expanded, metricLabels("algo", string(algo)), expandedBuckets).observe(float64(result.Expanded))
+}
+
+// Record how long rendering an output in format has taken
+func (sm *serverMetrics) rendered(format string, d time.Duration) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	histogramOf(sm.render, metricLabels("format", format), secondBuckets).observe(d.Seconds())
+}
+
+// Write a counter, its series sorted by labels
+func writeCounter(w io.Writer, name, help string, series map[string]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, labels := range slices.Sorted(maps.Keys(series)) {
+		fmt.Fprintf(w, "%s{%s} %d\n", name, labels, series[labels])
+	}
+}
+
+// Write a histogram, its series sorted by labels. The buckets are cumulated as Prometheus expects
+func writeHistogram(w io.Writer, name, help string, series map[string]*histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	for _, labels := range slices.Sorted(maps.Keys(series)) {
+		h := series[labels]
+		var cumulated uint64
+		for i, bound := range h.bounds {
+			cumulated += h.counts[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, strconv.FormatFloat(bound, 'g', -1, 64), cumulated)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, h.count)
+	}
+}
+
+// Write every metric in the text format of Prometheus
+func (sm *serverMetrics) write(w io.Writer) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	writeCounter(w, "maze_server_solves_total", "Solves that have ended, with or without a solution.", sm.solves)
+	writeCounter(w, "maze_server_errors_total", "Requests that have failed, by reason.", sm.errors)
+	writeHistogram(w, "maze_server_solve_duration_seconds", "How long the solves have taken.", sm.duration)
+	writeHistogram(w, "maze_server_nodes_expanded", "Nodes expanded by the solves.", sm.expanded)
+	writeHistogram(w, "maze_server_render_duration_seconds", "How long rendering the answers has taken.", sm.render)
+}
+
+// Answer the metrics in the text format of Prometheus
+func (sm *serverMetrics) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	sm.write(w)
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A client connection to a NATS server, enough to consume a subject in a queue group and publish on others: the text
+// protocol of NATS core, without headers, JetStream or TLS
+type natsConn struct {
+	conn       net.Conn
+	r          *bufio.Reader
+	w          *bufio.Writer
+	mu         sync.Mutex // Held while writing, pongs are written by the reader
+	maxPayload int
+	draining   bool // Set by Drain, under mu
+}
+
+// A message received on a subscription
+type natsMsg struct {
+	Subject string
+	Reply   string // Where the answer is expected, empty when none is
+	Data    []byte
+}
+
+// The fields of the INFO the server sends first that the client looks at
+type natsInfo struct {
+	MaxPayload  int  `json:"max_payload"`
+	TLSRequired bool `json:"tls_required"`
+}
+
+var (
+	// Returned by Publish when the message is larger than the max payload of the server
+	errNATSPayloadTooLarge = errors.New("message larger than the max payload of the NATS server")
+
+	// Returned by Next once Drain has been called and every message sent before has been read
+	errNATSDrained = errors.New("NATS subscription drained")
+)
+
+// Connect to the NATS server of rawURL: nats://host:port, with user:pass@ or token@ for the servers asking for them.
+// The port is 4222 by default. name is how the connection shows in the monitoring of the server
+func dialNATS(ctx context.Context, rawURL, name string) (*natsConn, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "nats://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" {
+		return nil, fmt.Errorf("unsupported NATS URL %q, expected nats://host:port", rawURL)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	nc := &natsConn{conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+	if err := nc.handshake(u, name); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to %s: %v", host, err)
+	}
+
+	return nc, nil
+}
+
+// Read the INFO of the server, send the CONNECT and wait for the PONG of a PING, which tells the connection is accepted
+func (nc *natsConn) handshake(u *url.URL, name string) error {
+	nc.conn.SetDeadline(time.Now().Add(10 * time.Second))
+	defer nc.conn.SetDeadline(time.Time{})
+
+	line, err := nc.readLine()
+	if err != nil {
+		return err
+	}
+	op, args, _ := strings.Cut(line, " ")
+	if op != "INFO" {
+		return fmt.Errorf("expected INFO, got %q", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(args), &info); err != nil {
+		return fmt.Errorf("invalid INFO: %v", err)
+	}
+	if info.TLSRequired {
+		return errors.New("the server requires TLS, which isn't supported")
+	}
+	nc.maxPayload = info.MaxPayload
+
+	connect := map[string]any{"verbose": false, "pedantic": false, "lang": "go", "name": name, "protocol": 1}
+	if pass, ok := u.User.Password(); ok {
+		connect["user"], connect["pass"] = u.User.Username(), pass
+	} else if u.User != nil {
+		connect["auth_token"] = u.User.Username()
+	}
+	data, err := json.Marshal(connect)
+	if err != nil {
+		return err
+	}
+	if err := nc.write("CONNECT "+string(data)+"\r\nPING\r\n", nil); err != nil {
+		return err
+	}
+
+	for {
+		line, err := nc.readLine()
+		if err != nil {
+			return err
+		}
+		switch op, args, _ := strings.Cut(line, " "); op {
+		case "PONG":
+			return nil
+		case "-ERR":
+			return errors.New(strings.Trim(args, "'"))
+		}
+	}
+}
+
+// Read a line of the protocol, without its CRLF
+func (nc *natsConn) readLine() (string, error) {
+	line, err := nc.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Write a protocol line, followed by payload and a CRLF when payload isn't nil, and flush
+func (nc *natsConn) write(line string, payload []byte) error {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	nc.w.WriteString(line)
+	if payload != nil {
+		nc.w.Write(payload)
+		nc.w.WriteString("\r\n")
+	}
+
+	return nc.w.Flush()
+}
+
+// Subscribe to subject as a member of the queue group queue, so each message goes to one member only. sid identifies
+// the subscription on the connection
+func (nc *natsConn) Subscribe(subject, queue string, sid int) error {
+	return nc.write(fmt.Sprintf("SUB %s %s %d\r\n", subject, queue, sid), nil)
+}
+
+// Stop the subscription sid, and make Next return errNATSDrained once the messages already sent to it are read.
+// Publishing still works
+func (nc *natsConn) Drain(sid int) error {
+	nc.mu.Lock()
+	nc.draining = true
+	nc.mu.Unlock()
+
+	// The server answers the PING after every message it sent before the UNSUB
+	return nc.write(fmt.Sprintf("UNSUB %d\r\nPING\r\n", sid), nil)
+}
+
+// Publish data on subject
+func (nc *natsConn) Publish(subject string, data []byte) error {
+	if nc.maxPayload > 0 && len(data) > nc.maxPayload {
+		return errNATSPayloadTooLarge
+	}
+
+	return nc.write(fmt.Sprintf("PUB %s %d\r\n", subject, len(data)), data)
+}
+
+// Wait for the next message of the subscriptions, answering the pings of the server meanwhile
+func (nc *natsConn) Next() (natsMsg, error) {
+	for {
+		line, err := nc.readLine()
+		if err != nil {
+			return natsMsg{}, err
+		}
+
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(args)
+			if len(fields) != 3 && len(fields) != 4 {
+				return natsMsg{}, fmt.Errorf("invalid MSG %q", line)
+			}
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || size < 0 || nc.maxPayload > 0 && size > nc.maxPayload {
+				return natsMsg{}, fmt.Errorf("invalid MSG %q", line)
+			}
+
+			msg := natsMsg{Subject: fields[0], Data: make([]byte, size+2)}
+			if len(fields) == 4 {
+				msg.Reply = fields[2]
+			}
+			if _, err := io.ReadFull(nc.r, msg.Data); err != nil {
+				return natsMsg{}, err
+			}
+			msg.Data = msg.Data[:size]
+			return msg, nil
+		case "PING":
+			if err := nc.write("PONG\r\n", nil); err != nil {
+				return natsMsg{}, err
+			}
+		case "PONG":
+			nc.mu.Lock()
+			draining := nc.draining
+			nc.mu.Unlock()
+			if draining {
+				return natsMsg{}, errNATSDrained
+			}
+		case "-ERR":
+			return natsMsg{}, fmt.Errorf("NATS server error: %s", strings.Trim(args, "'"))
+		}
+		// +OK and the INFO updates of the cluster are ignored
+	}
+}
+
+// Close the connection
+func (nc *natsConn) Close() error {
+	return nc.conn.Close()
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// A JSON object of the OpenAPI document
+type object = map[string]any
+
+// The algorithms the server accepts, in the order of the help of solve
+var serverAlgos = slices.Concat(solve.AllAlgos, []maze.Algo{maze.PARALLEL_DIJKSTRA})
+
+// The formats POST /solve answers
+var solveFormats = []string{"json", "png", "gif"}
+
+// Get a reference to a schema of the components
+func schemaRef(name string) object {
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+// Get a plain text response
+func textResponse(description string) object {
+	return object{"description": description, "content": object{"text/plain": object{"schema": object{"type": "string"}}}}
+}
+
+// Get the query parameters of a solve request, with the enums the handlers validate against
+func solveQueryParameters() []object {
+	return []object{
+		{"name": "algo", "in": "query", "description": "The search algorithm",
+			"schema": object{"type": "string", "enum": serverAlgos, "default": maze.ASTAR}},
+		{"name": "heuristic", "in": "query", "description": "The heuristic of gbfs and astar, the default of the algorithm when not set",
+			"schema": object{"type": "string", "enum": solve.HeuristicNames()}},
+		{"name": "movement", "in": "query", "description": "How the solver moves: four (up, down, left, right) or eight (diagonals too)",
+			"schema": object{"type": "string", "enum": maze.TopologyNames(), "default": "four"}},
+	}
+}
+
+// Add the answers of the requests refused by guard to the responses of a solving route
+func guardedResponses(responses object) object {
+	responses["401"] = textResponse("Invalid API key, or no key when the server requires one")
+	responses["429"] = textResponse("The client has sent too many requests, see the Retry-After header")
+	return responses
+}
+
+// Build the OpenAPI 3 document of the HTTP API. The enums and the size limits are the ones the handlers check, so the
+// document can't get out of date with them
+func openAPIDocument(cfg ServeConfig) object {
+	mazeLimits := fmt.Sprintf("The maze text, in the format of the maze files: # is a wall, A the start, B the goal, 1 to 9 "+
+		"the cost of a square. At most %d bytes", cfg.MaxBytes)
+	if cfg.MaxCells > 0 {
+		mazeLimits += fmt.Sprintf(" and %d squares (height x width)", cfg.MaxCells)
+	}
+	mazeLimits += "."
+	point := object{"type": "object", "required": []string{"row", "col"}, "properties": object{
+		"row": object{"type": "integer"},
+		"col": object{"type": "integer"},
+	}}
+	integer := object{"type": "integer"}
+
+	// Any of the ways to send the key, or none when the server doesn't require one
+	security := []object{{"bearerKey": []string{}}, {"headerKey": []string{}}, {"queryKey": []string{}}}
+	if !cfg.RequireKey {
+		security = append([]object{{}}, security...)
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":       "Maze solver",
+			"version":     "1",
+			"description": "Solve mazes with uninformed and informed search algorithms.",
+		},
+		"paths": object{
+			"/solve": object{"post": object{
+				"operationId": "solve",
+				"summary":     "Solve a maze",
+				"description": "A maze without a solution is still answered, with an empty path.",
+				"parameters": append(solveQueryParameters(), object{
+					"name": "format", "in": "query", "description": "The answer: the JSON export, the solution image or the solving animation",
+					"schema": object{"type": "string", "enum": solveFormats, "default": "json"},
+				}),
+				"requestBody": object{"required": true, "description": mazeLimits, "content": object{
+					"text/plain": object{"schema": object{"type": "string", "maxLength": cfg.MaxBytes}},
+				}},
+				"security": security,
+				"responses": guardedResponses(object{
+					"200": object{"description": "The solve, in the format asked", "content": object{
+						"application/json": object{"schema": schemaRef("Export")},
+						"image/png":        object{"schema": object{"type": "string", "format": "binary"}},
+						"image/gif":        object{"schema": object{"type": "string", "format": "binary"}},
+					}},
+					"400": textResponse("Invalid parameters or maze"),
+					"413": textResponse("The maze is larger than the limits of the server"),
+					"422": textResponse("The animation would be larger than the GIF memory budget"),
+					"504": textResponse("The solve took longer than the timeout of the server"),
+				}),
+			}},
+			"/solve/stream": object{"get": object{
+				"operationId": "solveStream",
+				"summary":     "Solve a maze over a WebSocket",
+				"description": "Upgrades to a WebSocket. Send the maze text as the first message (" + mazeLimits + "), then " +
+					"every message received is a StreamMessage: a maze message, one expand or enqueue message per event of " +
+					"the solve, and a done or error message last.",
+				"parameters": solveQueryParameters(),
+				"security":   security,
+				"responses": guardedResponses(object{
+					"101": object{"description": "Switched to a WebSocket of StreamMessage", "content": object{
+						"application/json": object{"schema": schemaRef("StreamMessage")},
+					}},
+					"400": textResponse("Invalid parameters or not a WebSocket handshake"),
+					"403": textResponse("The handshake comes from a web page of an origin that isn't allowed"),
+				}),
+			}},
+			"/jobs": object{"post": object{
+				"operationId": "submitJob",
+				"summary":     "Queue the solve of a large maze",
+				"description": "Takes the same request as POST /solve and answers right away with the status of the job, " +
+					"to be polled with GET /jobs/{id}.",
+				"parameters": append(solveQueryParameters(), object{
+					"name": "artifacts", "in": "query", "style": "form", "explode": false,
+					"description": "The formats to write into the storage of the server once solved, their URLs are reported " +
+						"with the job. Refused when the server has no storage",
+					"schema": object{"type": "array", "items": object{"type": "string", "enum": solveFormats}},
+				}),
+				"requestBody": object{"required": true, "description": mazeLimits, "content": object{
+					"text/plain": object{"schema": object{"type": "string", "maxLength": cfg.MaxBytes}},
+				}},
+				"security": security,
+				"responses": guardedResponses(object{
+					"202": object{"description": "The job is queued", "content": object{
+						"application/json": object{"schema": schemaRef("Job")},
+					}},
+					"400": textResponse("Invalid parameters or maze"),
+					"413": textResponse("The maze is larger than the limits of the server"),
+					"503": textResponse("Too many jobs are waiting, see the Retry-After header"),
+				}),
+			}},
+			"/jobs/{id}": object{"get": object{
+				"operationId": "getJob",
+				"summary":     "Get the state and progress of a job, and its result once done",
+				"parameters":  []object{{"name": "id", "in": "path", "required": true, "schema": object{"type": "string"}}},
+				"responses": object{
+					"200": object{"description": "The job", "content": object{
+						"application/json": object{"schema": schemaRef("Job")},
+					}},
+					"404": textResponse("No such job, or it has finished too long ago"),
+				},
+			}},
+			"/metrics": object{"get": object{
+				"operationId": "metrics",
+				"summary":     "The metrics of the solves, in the text format of Prometheus",
+				"responses":   object{"200": textResponse("The metrics")},
+			}},
+			"/openapi.json": object{"get": object{
+				"operationId": "openAPI",
+				"summary":     "This document",
+				"responses":   object{"200": object{"description": "The OpenAPI document", "content": object{"application/json": object{}}}},
+			}},
+		},
+		"components": object{
+			"securitySchemes": object{
+				"bearerKey": object{"type": "http", "scheme": "bearer", "description": "An API key of the server"},
+				"headerKey": object{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"queryKey":  object{"type": "apiKey", "in": "query", "name": "api_key", "description": "For the WebSocket of browsers"},
+			},
+			"schemas": object{
+				"Point": point,
+				"Stats": object{"type": "object", "properties": object{
+					"nodes_expanded":  integer,
+					"nodes_generated": integer,
+					"frontier_peak":   integer,
+					"path_length":     integer,
+					"path_cost":       integer,
+					"solve_time_ns":   integer,
+					"bytes_alloc":     integer,
+					"allocs":          integer,
+				}},
+				"Result": object{"type": "object", "properties": object{
+					"algorithm":       object{"type": "string", "enum": serverAlgos},
+					"parameters":      object{"type": "object", "additionalProperties": object{"type": "string"}},
+					"solved":          object{"type": "boolean"},
+					"unreachable":     object{"type": "boolean"},
+					"path":            object{"type": "array", "items": schemaRef("Point")},
+					"actions":         object{"type": "array", "items": object{"type": "string"}},
+					"path_length":     integer,
+					"path_cost":       integer,
+					"nodes_explored":  integer,
+					"frontier_peak":   integer,
+					"time_seconds":    object{"type": "number"},
+					"nodes_expanded":  integer,
+					"nodes_generated": integer,
+					"bytes_alloc":     integer,
+					"allocs":          integer,
+				}},
+				"Export": object{"type": "object", "properties": object{
+					"maze":    object{"type": "string"},
+					"width":   integer,
+					"height":  integer,
+					"start":   schemaRef("Point"),
+					"goal":    schemaRef("Point"),
+					"results": object{"type": "array", "items": schemaRef("Result")},
+				}},
+				"Job": object{"type": "object", "required": []string{"id", "state"}, "properties": object{
+					"id":          object{"type": "string"},
+					"state":       object{"type": "string", "enum": []jobState{jobQueued, jobRunning, jobDone, jobFailed}},
+					"algo":        object{"type": "string", "enum": serverAlgos},
+					"created_at":  object{"type": "string", "format": "date-time"},
+					"started_at":  object{"type": "string", "format": "date-time"},
+					"finished_at": object{"type": "string", "format": "date-time"},
+					"expanded":    integer,
+					"frontier":    integer,
+					"open":        integer,
+					"progress":    object{"type": "number", "minimum": 0, "maximum": 1},
+					"error":       object{"type": "string"},
+					"result":      schemaRef("Export"),
+					"artifacts":   object{"type": "object", "additionalProperties": object{"type": "string", "format": "uri"}},
+				}},
+				"StreamMessage": object{"type": "object", "required": []string{"kind"}, "properties": object{
+					"kind":     object{"type": "string", "enum": []string{"maze", "expand", "enqueue", "done", "error"}},
+					"point":    schemaRef("Point"),
+					"frontier": integer,
+					"explored": integer,
+					"height":   integer,
+					"width":    integer,
+					"start":    schemaRef("Point"),
+					"goal":     schemaRef("Point"),
+					"path":     object{"type": "array", "items": schemaRef("Point")},
+					"stats":    schemaRef("Stats"),
+					"error":    object{"type": "string"},
+				}},
+			},
+		},
+	}
+}
+
+// Answer the OpenAPI document of the HTTP API
+func (cfg ServeConfig) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	data, err := json.MarshalIndent(openAPIDocument(cfg), "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The default result filename template
+const DefaultNameTemplate = "{maze}_{algo}.{ext}"
+
+// Placeholders supported in result filename templates
+var namePlaceholders = []string{"{maze}", "{algo}", "{ext}", "{timestamp}", "{date}"}
+
+// Get the path of a result file: <dir>/<maze name>_<algo>.<ext>, where the maze name is the input file name without
+// its directories and extension (mazes/maze.txt -> maze)
+func CreateResultFilename(dir, input, algo, ext string) string {
+	return FormatResultFilename(DefaultNameTemplate, dir, input, algo, ext, maze.Now())
+}
+
+// Get the path of a result file from a template. Supported placeholders are {maze} (input file name without directories
+// and extension), {algo}, {ext}, {timestamp} (20060102-150405) and {date} (2006-01-02), all taken at time t
+func FormatResultFilename(template, dir, input, algo, ext string, t time.Time) string {
+	replacer := strings.NewReplacer(
+		"{maze}", mazeName(input),
+		"{algo}", algo,
+		"{ext}", ext,
+		"{timestamp}", t.Format("20060102-150405"),
+		"{date}", t.Format("2006-01-02"),
+	)
+
+	return filepath.Join(dir, replacer.Replace(template))
+}
+
+// Get the name of a maze from its input file: the file name without directories and extension, or stdin for -
+func mazeName(input string) string {
+	if input == "-" {
+		return "stdin"
+	}
+
+	return strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+}
+
+// Check if a result filename template only uses supported placeholders and contains {ext}, so different kinds of
+// result don't overwrite each other
+func ValidateNameTemplate(template string) error {
+	rest := template
+	for _, placeholder := range namePlaceholders {
+		rest = strings.ReplaceAll(rest, placeholder, "")
+	}
+
+	if strings.Contains(rest, "{") {
+		return fmt.Errorf("unknown placeholder in name template %q, supported: %s", template, strings.Join(namePlaceholders, ", "))
+	}
+
+	if !strings.Contains(template, "{ext}") {
+		return fmt.Errorf("name template %q must contain {ext}", template)
+	}
+
+	return nil
+}
+
+// Write a result file, creating its directory if needed. An existing file is only overwritten if force is set
+func WriteResult(path string, data []byte, force bool) error {
+	file, err := CreateResultFile(path, force)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Create the result file at path for writing, with the same rules as WriteResult
+func CreateResultFile(path string, force bool) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+
+	file, err := os.OpenFile(path, flags, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("%s already exists, use -force to overwrite it", path)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Read a maze input file, - reads stdin. The text is decoded with maze.DecodeText, then the blank lines and the whitespace around the text are dropped, but not the
+// spaces at the start of its first line, which are open squares of a maze
+func ReadFile(input string) (string, error) {
+	var data []byte
+	var err error
+	if input == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(input)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text := strings.TrimRight(maze.DecodeText(string(data)), " \t\r\n")
+	for {
+		line, rest, ok := strings.Cut(text, "\n")
+		if !ok || strings.TrimSpace(line) != "" {
+			break
+		}
+		text = rest
+	}
+
+	return text, nil
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// Returned when the user leaves a picker with q or Esc
+var errPickerCancelled = errors.New("cancelled from the menu")
+
+// Check if menus can be shown: single key presses can be read from stdin, and both stdin and stderr are terminals
+func interactive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stderr} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+
+	return singleKeys
+}
+
+// Check if the flag name has been set on the command line
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+
+	return set
+}
+
+// Show a menu of labels on out, moved through with the arrow keys (or j and k) read from in. With multi, space checks
+// and unchecks the item under the cursor and Enter confirms the checked items, at least one is needed. Otherwise, Enter
+// picks the item under the cursor. checked is the initial choice, and is updated with the final one
+func pick(in io.Reader, out io.Writer, title string, labels []string, checked []bool, multi bool) error {
+	cursor := max(0, slices.Index(checked, true))
+	draw := func() {
+		for i, label := range labels {
+			pointer, box := "  ", "( )"
+			if i == cursor {
+				pointer = "> "
+			}
+			if multi {
+				box = "[ ]"
+				if checked[i] {
+					box = "[x]"
+				}
+			} else if i == cursor {
+				box = "(*)"
+			}
+			fmt.Fprintf(out, "\r\033[K%s%s %s\n", pointer, box, label)
+		}
+	}
+
+	hint := "arrows to move, Enter to pick, q to quit"
+	if multi {
+		hint = "arrows to move, space to check, Enter to confirm, q to quit"
+	}
+	fmt.Fprintf(out, "%s (%s)\n", title, hint)
+	draw()
+
+	// An arrow key is sent as Esc [ A (up) or Esc [ B (down), possibly split over several reads
+	buf := make([]byte, 16)
+	escape := 0
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range buf[:n] {
+			switch {
+			case escape == 1 && key == '[':
+				escape = 2
+				continue
+			case escape == 2:
+				// Other escape sequences (e.g. the left and right arrows) are ignored
+				escape = 0
+				if key == 'A' {
+					cursor = (cursor + len(labels) - 1) % len(labels)
+				} else if key == 'B' {
+					cursor = (cursor + 1) % len(labels)
+				}
+				continue
+			case escape == 1:
+				// Esc followed by something else than [
+				return errPickerCancelled
+			}
+
+			switch key {
+			case 27:
+				escape = 1
+			case 'k':
+				cursor = (cursor + len(labels) - 1) % len(labels)
+			case 'j':
+				cursor = (cursor + 1) % len(labels)
+			case ' ':
+				if multi {
+					checked[cursor] = !checked[cursor]
+				}
+			case '\r', '\n':
+				if !multi {
+					for i := range checked {
+						checked[i] = i == cursor
+					}
+					return nil
+				}
+				if slices.Index(checked, true) >= 0 {
+					return nil
+				}
+			case 'q':
+				return errPickerCancelled
+			}
+		}
+
+		// Esc alone is only known once the read has ended without what follows it
+		if escape == 1 && n == 1 {
+			return errPickerCancelled
+		}
+
+		fmt.Fprintf(out, "\033[%dA", len(labels))
+		draw()
+	}
+}
+
+// The choices of the output menu, and the PNG and GIF flags each one sets
+var outputChoices = []struct {
+	label    string
+	png, gif bool
+}{
+	{"PNG and GIF", true, true},
+	{"PNG only", true, false},
+	{"GIF only (slow on big mazes)", false, true},
+	{"No image", false, false},
+}
+
+// Ask the algorithms to run with the terminal, all of algos checked at first when multi, else the first one picked
+func pickAlgos(algos []maze.Algo, multi bool) ([]maze.Algo, error) {
+	restore, err := rawMode()
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	labels := make([]string, len(algos))
+	checked := make([]bool, len(algos))
+	for i, algo := range algos {
+		labels[i] = string(algo)
+		checked[i] = multi || i == 0
+	}
+
+	title := "Algorithm to solve with"
+	if multi {
+		title = "Algorithms to compare"
+	}
+	if err := pick(os.Stdin, os.Stderr, title, labels, checked, multi); err != nil {
+		return nil, err
+	}
+
+	var picked []maze.Algo
+	for i, algo := range algos {
+		if checked[i] {
+			picked = append(picked, algo)
+		}
+	}
+
+	return picked, nil
+}
+
+// Ask which images to write with the terminal, and set the PNG and GIF flags of f from the answer
+func (f *runFlags) pickOutputs() error {
+	restore, err := rawMode()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	labels := make([]string, len(outputChoices))
+	checked := make([]bool, len(outputChoices))
+	for i, choice := range outputChoices {
+		labels[i] = choice.label
+	}
+	checked[0] = true
+
+	if err := pick(os.Stdin, os.Stderr, "Images to write", labels, checked, false); err != nil {
+		return err
+	}
+
+	choice := outputChoices[slices.Index(checked, true)]
+	f.png, f.gif, f.noOutput = choice.png, choice.gif, !choice.png && !choice.gif
+	return nil
+}
+
+// Ask the algorithms, and the images when no flag has chosen them, when the command runs in a terminal without -search.
+// algos is returned unchanged otherwise
+func (f *runFlags) pickInteractively(fs *flag.FlagSet, algos []maze.Algo, multi bool) ([]maze.Algo, error) {
+	if isFlagSet(fs, "search") || !interactive() {
+		return algos, nil
+	}
+
+	choices := algos
+	if !multi {
+		// The default algorithm first, then the others
+		others := slices.DeleteFunc(slices.Concat(solve.AllAlgos, []maze.Algo{maze.PARALLEL_DIJKSTRA}),
+			func(a maze.Algo) bool { return a == algos[0] })
+		choices = append([]maze.Algo{algos[0]}, others...)
+	}
+
+	picked, err := pickAlgos(choices, multi)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.renderMode == "image" && !f.png && !f.gif && !f.noOutput && !f.cfg.NoTrace && !isFlagSet(fs, "format") {
+		if err := f.pickOutputs(); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, len(picked))
+	for i, algo := range picked {
+		names[i] = string(algo)
+	}
+	LOGGER.Info("Picked from the menu, pass them as flags to skip it", "search", strings.Join(names, ","),
+		"png", f.png, "gif", f.gif)
+	return picked, nil
+}
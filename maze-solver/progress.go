@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A progress bar redrawn in place on the terminal. Its methods can be called from several goroutines, and do nothing
+// on a nil bar
+type Progress struct {
+	mu    sync.Mutex
+	w     io.Writer
+	label string
+	done  int
+	total int
+	drawn time.Time // When the bar was last drawn
+}
+
+const (
+	progressWidth    = 30                     // Number of characters of the bar itself
+	progressInterval = 100 * time.Millisecond // The bar is redrawn at most this often, and when it's full
+)
+
+// Create a progress bar on stderr. It's nil when stderr isn't a terminal, or when the logs are quiet or verbose, since
+// the bar would be mixed with the log lines
+func NewProgress(label string, total int) *Progress {
+	if VERBOSITY != 0 {
+		return nil
+	}
+
+	info, err := os.Stderr.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+
+	return &Progress{w: os.Stderr, label: label, total: total}
+}
+
+// Add n to the work done
+func (p *Progress) Add(n int) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	p.draw()
+}
+
+// Set the work done and the total work
+func (p *Progress) Update(done, total int) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done, p.total = done, total
+	p.draw()
+}
+
+// Erase the bar, so something else can be printed on its line. It's drawn again on the next update
+func (p *Progress) Clear() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(p.w, "\r\033[K")
+	p.drawn = time.Time{}
+}
+
+// Draw the bar if it hasn't been drawn for a while. Must be called with the lock held
+func (p *Progress) draw() {
+	if p.total <= 0 || (p.done < p.total && time.Since(p.drawn) < progressInterval) {
+		return
+	}
+	p.drawn = time.Now()
+
+	ratio := min(float64(p.done)/float64(p.total), 1)
+	filled := int(ratio * progressWidth)
+	fmt.Fprintf(p.w, "\r\033[K%s [%s%s] %3.0f%% (%d/%d)", p.label, strings.Repeat("#", filled),
+		strings.Repeat(" ", progressWidth-filled), ratio*100, p.done, p.total)
+}
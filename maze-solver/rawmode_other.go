@@ -0,0 +1,11 @@
+//go:build !linux
+
+package main
+
+// Single key presses are only supported on Linux, elsewhere the keys of the live view need Enter to be sent
+const singleKeys = false
+
+// Leave the terminal as it is, see singleKeys
+func rawMode() (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// A client of the HTTP API of the serve command
+type RemoteClient struct {
+	Server string       // The URL of the server, e.g. http://solver:8080
+	Key    string       // The API key sent to the server, none when empty
+	Client *http.Client // http.DefaultClient when nil
+}
+
+// The sub-commands of the remote command, in the order they are listed
+var remoteCommands = []Command{
+	{Name: "solve", Summary: "Solve a maze on the server and write the answer", Run: RemoteSolveCommand},
+	{Name: "submit", Summary: "Queue the solve of a large maze as a job, and optionally wait for it and download it", Run: RemoteSubmitCommand},
+	{Name: "status", Summary: "Show the state and progress of a job, optionally until it ends", Run: RemoteStatusCommand},
+	{Name: "download", Summary: "Download the result and the artifacts of a finished job", Run: RemoteDownloadCommand},
+}
+
+// Run the remote command: run the sub-command named by the first argument
+func RemoteCommand(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		remoteUsage(os.Stderr)
+		if len(args) == 0 {
+			return errors.New("remote needs a sub-command")
+		}
+		return flag.ErrHelp
+	}
+
+	for _, c := range remoteCommands {
+		if c.Name == args[0] {
+			return c.Run(args[1:])
+		}
+	}
+
+	remoteUsage(os.Stderr)
+	return fmt.Errorf("unknown remote sub-command %q", args[0])
+}
+
+// Print the usage of the remote command and its list of sub-commands
+func remoteUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: maze-solver remote <sub-command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Drive a server started with the serve command: heavy solves run on the server, the results come back here.")
+	fmt.Fprintln(w, "The server is given by -server, or by the MAZE_SERVER environment variable, and its API key by -key or MAZE_API_KEY.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Sub-commands:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range remoteCommands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.Name, c.Summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'maze-solver remote <sub-command> -h' for the flags of a sub-command")
+}
+
+// Add the -server and -key flags to fs
+func serverFlag(fs *flag.FlagSet) *RemoteClient {
+	c := &RemoteClient{}
+	fs.StringVar(&c.Server, "server", cmp.Or(os.Getenv("MAZE_SERVER"), "http://localhost:8080"),
+		"The URL of the server, $MAZE_SERVER by default")
+	fs.StringVar(&c.Key, "key", os.Getenv("MAZE_API_KEY"), "The API key of the server, $MAZE_API_KEY by default")
+	return c
+}
+
+// The parameters of a solve, as the query of the requests
+type remoteParams struct {
+	input, algo, heuristic, movement string
+}
+
+// Add the flags of the maze and the parameters of the solve to fs
+func (p *remoteParams) flags(fs *flag.FlagSet) {
+	fs.StringVar(&p.input, "maze", "mazes/maze.txt", "The maze input file, - reads it from stdin")
+	fs.StringVar(&p.algo, "algo", "", "The search algorithm, the default of the server when empty")
+	fs.StringVar(&p.heuristic, "heuristic", "", "The heuristic of gbfs and astar, the default of the algorithm when empty")
+	fs.StringVar(&p.movement, "movement", "", "How the solver moves: four or eight, four when empty")
+}
+
+// Get the query of the parameters, with the extra parameters in extra
+func (p remoteParams) query(extra ...string) url.Values {
+	query := url.Values{}
+	for i := 0; i+1 < len(extra); i += 2 {
+		if extra[i+1] != "" {
+			query.Set(extra[i], extra[i+1])
+		}
+	}
+	for name, value := range map[string]string{"algo": p.algo, "heuristic": p.heuristic, "movement": p.movement} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+
+	return query
+}
+
+// Send a request to the server. An answer other than a 2xx is returned as an error with the message of the server
+func (c *RemoteClient) do(ctx context.Context, method, target string, body io.Reader) (*http.Response, error) {
+	base, u, err := c.resolve(target)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/plain")
+	}
+	// The artifacts can be on another host, e.g. a bucket, which must not get the key
+	if c.Key != "" && u.Host == base.Host {
+		req.Header.Set("Authorization", "Bearer "+c.Key)
+	}
+
+	resp, err := cmp.Or(c.Client, http.DefaultClient).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		err := fmt.Errorf("the server answered %s: %s", resp.Status, strings.TrimSpace(string(message)))
+		if retry := resp.Header.Get("Retry-After"); retry != "" {
+			err = fmt.Errorf("%v (retry after %ss)", err, retry)
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Get the URL of the server and the absolute URL of target, relative to the server unless it's absolute already
+func (c *RemoteClient) resolve(target string) (*url.URL, *url.URL, error) {
+	base, err := url.Parse(strings.TrimSuffix(c.Server, "/") + "/")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid server URL %q: %v", c.Server, err)
+	}
+	ref, err := url.Parse(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return base, base.ResolveReference(ref), nil
+}
+
+// Solve a maze with POST /solve and get the answer, in the format of the query
+func (c *RemoteClient) Solve(ctx context.Context, text string, query url.Values) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodPost, "solve?"+query.Encode(), strings.NewReader(text))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// Queue the solve of a maze with POST /jobs and get its job
+func (c *RemoteClient) Submit(ctx context.Context, text string, query url.Values) (jobStatus, error) {
+	return c.decodeJob(c.do(ctx, http.MethodPost, "jobs?"+query.Encode(), strings.NewReader(text)))
+}
+
+// Get the status of the job id
+func (c *RemoteClient) Job(ctx context.Context, id string) (jobStatus, error) {
+	return c.decodeJob(c.do(ctx, http.MethodGet, "jobs/"+url.PathEscape(id), nil))
+}
+
+// Decode the job answered by the server
+func (c *RemoteClient) decodeJob(resp *http.Response, err error) (jobStatus, error) {
+	if err != nil {
+		return jobStatus{}, err
+	}
+	defer resp.Body.Close()
+
+	var status jobStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return jobStatus{}, fmt.Errorf("invalid job: %v", err)
+	}
+	return status, nil
+}
+
+// Poll the job id every interval until it's done or failed, calling onStatus with every status polled
+func (c *RemoteClient) Wait(ctx context.Context, id string, interval time.Duration, onStatus func(jobStatus)) (jobStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := c.Job(ctx, id)
+		if err != nil {
+			return status, err
+		}
+		onStatus(status)
+		if status.State == jobDone || status.State == jobFailed {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Download a file, its URL is relative to the server unless absolute
+func (c *RemoteClient) Download(ctx context.Context, target string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// Wait for the job id, showing its progress, and log how it has ended
+func (c *RemoteClient) waitJob(ctx context.Context, id string, interval time.Duration) (jobStatus, error) {
+	var progress *Progress
+	status, err := c.Wait(ctx, id, interval, func(status jobStatus) {
+		if status.State != jobRunning {
+			return
+		}
+		if progress == nil {
+			progress = NewProgress(fmt.Sprintf("Solving job %s with %s", id, status.Algo), status.Open)
+		}
+		progress.Update(int(status.Expanded), status.Open)
+	})
+	progress.Clear()
+	if err != nil {
+		return status, err
+	}
+
+	if status.State == jobFailed {
+		return status, fmt.Errorf("job %s has failed: %s", id, status.Error)
+	}
+	LOGGER.Info("Job done", "id", id, "algo", status.Algo, "expanded", status.Expanded)
+	return status, nil
+}
+
+// Write the JSON export and the artifacts of a finished job into dir, and get the paths written
+func (c *RemoteClient) downloadJob(ctx context.Context, status jobStatus, dir string, force bool) ([]string, error) {
+	if status.State != jobDone {
+		return nil, fmt.Errorf("job %s is %s, only a done job can be downloaded", status.ID, status.State)
+	}
+
+	output := filepath.Join(dir, "job_"+status.ID+".json")
+	if err := WriteResult(output, status.Result, force); err != nil {
+		return nil, err
+	}
+	written := []string{output}
+
+	for _, target := range status.Artifacts {
+		data, err := c.Download(ctx, target)
+		if err != nil {
+			return written, fmt.Errorf("failed to download %s: %v", target, err)
+		}
+
+		u, err := url.Parse(target)
+		if err != nil {
+			return written, err
+		}
+		output := filepath.Join(dir, path.Base(u.Path))
+		if err := WriteResult(output, data, force); err != nil {
+			return written, err
+		}
+		written = append(written, output)
+	}
+
+	return written, nil
+}
+
+// Print the status of a job
+func printJob(w io.Writer, status jobStatus) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Job\t%s\n", status.ID)
+	fmt.Fprintf(tw, "State\t%s\n", status.State)
+	fmt.Fprintf(tw, "Algorithm\t%s\n", status.Algo)
+	fmt.Fprintf(tw, "Created\t%s\n", status.CreatedAt.Local().Format(time.DateTime))
+	if status.StartedAt != nil {
+		end := time.Now()
+		if status.FinishedAt != nil {
+			end = *status.FinishedAt
+		}
+		fmt.Fprintf(tw, "Running for\t%s\n", end.Sub(*status.StartedAt).Round(time.Millisecond))
+	}
+	fmt.Fprintf(tw, "Progress\t%.1f%% (%d of %d open squares expanded, %d in the frontier)\n", 100*status.Progress,
+		status.Expanded, status.Open, status.Frontier)
+	if status.Error != "" {
+		fmt.Fprintf(tw, "Error\t%s\n", status.Error)
+	}
+	for format, target := range status.Artifacts {
+		fmt.Fprintf(tw, "Artifact %s\t%s\n", format, target)
+	}
+	tw.Flush()
+}
+
+// Run the remote solve command
+func RemoteSolveCommand(args []string) error {
+	var params remoteParams
+	var format, output string
+	var force bool
+	fs := newFlagSet("remote solve", "", "Solve a maze with POST /solve of the server and write the answer: the JSON export\n"+
+		"to stdout, or the PNG or GIF to a file. The solve must end within the timeout of the server, use remote submit\n"+
+		"for the larger mazes.")
+	client := serverFlag(fs)
+	params.flags(fs)
+	fs.StringVar(&format, "format", "json", "The answer: json, png or gif")
+	fs.StringVar(&output, "o", "", "Write the answer to this file. Empty means stdout for json, and <maze>_<algo>.<ext> otherwise")
+	fs.BoolVar(&force, "force", false, "Overwrite the existing output file")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	text, err := ReadFile(params.input)
+	if err != nil {
+		return fmt.Errorf("failed to read maze: %v", err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	data, err := client.Solve(ctx, text, params.query("format", format))
+	if err != nil {
+		return err
+	}
+
+	if output == "" && format == "json" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	output = cmp.Or(output, CreateResultFilename(".", params.input, cmp.Or(params.algo, "astar"), format))
+	if err := WriteResult(output, data, force); err != nil {
+		return err
+	}
+	LOGGER.Info("Write the answer of the server successfully", "path", output)
+	return nil
+}
+
+// Run the remote submit command
+func RemoteSubmitCommand(args []string) error {
+	var params remoteParams
+	var artifacts, out string
+	var wait, force bool
+	var interval time.Duration
+	fs := newFlagSet("remote submit", "", "Queue the solve of a maze with POST /jobs of the server and print the id of its\n"+
+		"job, to be followed with remote status and fetched with remote download. With -wait, the progress of the job\n"+
+		"is shown until it ends, and with -out its result and artifacts are downloaded then.")
+	client := serverFlag(fs)
+	params.flags(fs)
+	fs.StringVar(&artifacts, "artifacts", "", "Comma separated artifacts the server renders and stores once solved: png, gif\n"+
+		"and json. The server needs -storage")
+	fs.BoolVar(&wait, "wait", false, "Wait for the job to end, showing its progress")
+	fs.StringVar(&out, "out", "", "Download the result and artifacts of the job into this directory once done, implies -wait")
+	fs.DurationVar(&interval, "poll", time.Second, "How often the job is polled while waiting")
+	fs.BoolVar(&force, "force", false, "Overwrite the existing downloaded files")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	text, err := ReadFile(params.input)
+	if err != nil {
+		return fmt.Errorf("failed to read maze: %v", err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	status, err := client.Submit(ctx, text, params.query("artifacts", artifacts))
+	if err != nil {
+		return err
+	}
+	if !wait && out == "" {
+		fmt.Println(status.ID)
+		return nil
+	}
+
+	LOGGER.Info("Job submitted", "id", status.ID, "server", client.Server)
+	if status, err = client.waitJob(ctx, status.ID, interval); err != nil {
+		return err
+	}
+	if out == "" {
+		printJob(os.Stdout, status)
+		return nil
+	}
+
+	written, err := client.downloadJob(ctx, status, out, force)
+	for _, path := range written {
+		LOGGER.Info("Download successfully", "path", path)
+	}
+	return err
+}
+
+// Run the remote status command
+func RemoteStatusCommand(args []string) error {
+	var wait, asJSON bool
+	var interval time.Duration
+	fs := newFlagSet("remote status", " <job id>", "Show the state and progress of a job of the server.")
+	client := serverFlag(fs)
+	fs.BoolVar(&wait, "wait", false, "Wait for the job to end, showing its progress")
+	fs.DurationVar(&interval, "poll", time.Second, "How often the job is polled while waiting")
+	fs.BoolVar(&asJSON, "json", false, "Print the job as the server answers it, with the JSON export once done")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("remote status takes one job id, got %d arguments", fs.NArg())
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	var status jobStatus
+	var err error
+	if wait {
+		status, err = client.waitJob(ctx, fs.Arg(0), interval)
+	} else {
+		status, err = client.Job(ctx, fs.Arg(0))
+	}
+	if status.ID == "" {
+		return err
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(status)
+	} else {
+		printJob(os.Stdout, status)
+	}
+	return err
+}
+
+// Run the remote download command
+func RemoteDownloadCommand(args []string) error {
+	var out string
+	var force bool
+	fs := newFlagSet("remote download", " <job id>", "Download the result of a finished job of the server, as job_<id>.json,\n"+
+		"and the artifacts it has stored, named after their URLs.")
+	client := serverFlag(fs)
+	fs.StringVar(&out, "out", ".", "The directory to write the files into")
+	fs.BoolVar(&force, "force", false, "Overwrite the existing files")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("remote download takes one job id, got %d arguments", fs.NArg())
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	status, err := client.Job(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	written, err := client.downloadJob(ctx, status, out, force)
+	for _, path := range written {
+		LOGGER.Info("Download successfully", "path", path)
+	}
+	return err
+}
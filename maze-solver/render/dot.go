@@ -0,0 +1,65 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Create a GraphViz DOT graph of the search tree: every expanded node linked to its parent, labeled with its
+// coordinate, expansion order and cost. The start, goal and solution path are highlighted.
+// Render it with: dot -Tpng tree.dot -o tree.png
+func CreateDOT(m *maze.Solved) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+
+	// The solution path, to highlight its nodes
+	onPath := make(map[maze.Point]bool)
+	for _, p := range m.Solution.Path {
+		onPath[p] = true
+	}
+
+	// Each node is identified by its expansion order
+	ids := make(map[*maze.Node]string)
+	for i, node := range m.SearchTree {
+		ids[node] = fmt.Sprintf("n%d", i)
+	}
+
+	fmt.Fprintf(buf, "digraph %q {\n", fmt.Sprintf("%s search tree", m.SearchType))
+	fmt.Fprintln(buf, `	node [shape=box, fontname="monospace"];`)
+
+	for i, node := range m.SearchTree {
+		p := node.Square.Coordinate
+		attrs := ""
+		switch {
+		case p == m.Start:
+			attrs = `, style=filled, fillcolor="green"`
+		case p == m.Goal:
+			attrs = `, style=filled, fillcolor="red"`
+		case onPath[p]:
+			attrs = `, style=filled, fillcolor="violet"`
+		}
+
+		fmt.Fprintf(buf, "\t%s [label=\"(%d, %d)\\n#%d cost=%d\"%s];\n", ids[node], p.Row, p.Col, i, node.Cost, attrs)
+	}
+
+	for _, node := range m.SearchTree {
+		if node.Parent == nil {
+			continue
+		}
+
+		// The parent should always be expanded before its children, but just in case it isn't, declare it here
+		parent, ok := ids[node.Parent]
+		if !ok {
+			parent = fmt.Sprintf("p%p", node.Parent)
+			ids[node.Parent] = parent
+			pp := node.Parent.Square.Coordinate
+			fmt.Fprintf(buf, "\t%s [label=\"(%d, %d)\", style=dashed];\n", parent, pp.Row, pp.Col)
+		}
+
+		fmt.Fprintf(buf, "\t%s -> %s [label=%q];\n", parent, ids[node], node.Action)
+	}
+
+	fmt.Fprintln(buf, "}")
+	return buf, nil
+}
@@ -0,0 +1,156 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Columns of the CSV metrics export
+var CSVHeader = []string{
+	"timestamp", "maze", "algorithm", "solved", "path_length", "path_cost", "nodes_explored", "coverage",
+	"frontier_peak", "time_seconds", "nodes_expanded", "nodes_generated", "bytes_alloc", "allocs",
+}
+
+// Machine-readable result of one algorithm
+type ResultExport struct {
+	Algorithm     maze.Algo         `json:"algorithm"`
+	Parameters    map[string]string `json:"parameters"`
+	Solved        bool              `json:"solved"`
+	Unreachable   bool              `json:"unreachable"` // Every square reachable from the start has been explored without finding the goal
+	Path          []maze.Point      `json:"path"`
+	Actions       []maze.Action     `json:"actions"`
+	PathLength    int               `json:"path_length"`
+	PathCost      int               `json:"path_cost"`
+	NodesExplored int               `json:"nodes_explored"`
+	FrontierPeak  int               `json:"frontier_peak"`
+	TimeSeconds   float64           `json:"time_seconds"`
+	Expanded      int               `json:"nodes_expanded"`
+	Generated     int               `json:"nodes_generated"`
+	BytesAlloc    uint64            `json:"bytes_alloc"`
+	Allocs        uint64            `json:"allocs"`
+}
+
+// Machine-readable export of a maze and the result of every algorithm that solved it
+type Export struct {
+	Maze    string         `json:"maze"`
+	Width   int            `json:"width"`
+	Height  int            `json:"height"`
+	Start   maze.Point     `json:"start"`
+	Goal    maze.Point     `json:"goal"`
+	Results []ResultExport `json:"results"`
+}
+
+// Build the export of a solved maze
+func NewResultExport(m *maze.Solved) ResultExport {
+	return ResultExport{
+		Algorithm:     m.SearchType,
+		Parameters:    AlgoParameters(m.SearchType, m.Heuristic),
+		Solved:        m.Found(),
+		Unreachable:   m.Unreachable,
+		Path:          m.Solution.Path,
+		Actions:       m.Solution.Actions,
+		PathLength:    m.PathLength,
+		PathCost:      m.PathCost,
+		NodesExplored: len(m.Explored),
+		FrontierPeak:  m.FrontierPeak,
+		TimeSeconds:   m.SolveTime.Seconds(),
+		Expanded:      m.Expanded,
+		Generated:     m.Generated,
+		BytesAlloc:    m.BytesAlloc,
+		Allocs:        m.Allocs,
+	}
+}
+
+// Get the parameters the algorithm is run with, heuristic is the name of the heuristic of GBFS and A*
+func AlgoParameters(algo maze.Algo, heuristic string) map[string]string {
+	switch algo {
+	case maze.DFS:
+		return map[string]string{"frontier": "stack"}
+	case maze.BFS:
+		return map[string]string{"frontier": "queue"}
+	case maze.DIJKSTRA:
+		return map[string]string{"frontier": "priority queue", "priority": "path cost"}
+	case maze.PARALLEL_DIJKSTRA:
+		return map[string]string{"frontier": "cost buckets", "priority": "path cost"}
+	case maze.GBFS:
+		return map[string]string{"frontier": "priority queue", "priority": "heuristic", "heuristic": heuristic}
+	case maze.ASTAR:
+		return map[string]string{"frontier": "priority queue", "priority": "path cost + heuristic", "heuristic": heuristic}
+	}
+
+	return map[string]string{}
+}
+
+// Create the JSON export of the mazes. Every maze should be the same maze solved by a different algorithm
+func CreateJSON(name string, mazes []*maze.Solved) (*bytes.Buffer, error) {
+	if len(mazes) == 0 {
+		return nil, fmt.Errorf("no maze to export")
+	}
+
+	export := Export{
+		Maze:   name,
+		Width:  mazes[0].Width,
+		Height: mazes[0].Height,
+		Start:  mazes[0].Start,
+		Goal:   mazes[0].Goal,
+	}
+	for _, m := range mazes {
+		export.Results = append(export.Results, NewResultExport(m))
+	}
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %v", err)
+	}
+
+	return buf, nil
+}
+
+// Write one CSV row per algorithm into w, with the header first if withHeader is set.
+// The rows are meant to be appended to the same file across runs, so every row carries a timestamp
+func WriteCSV(w io.Writer, name string, mazes []*maze.Solved, withHeader bool) error {
+	writer := csv.NewWriter(w)
+	if withHeader {
+		if err := writer.Write(CSVHeader); err != nil {
+			return err
+		}
+	}
+
+	timestamp := maze.Now().Format(time.RFC3339)
+	for _, m := range mazes {
+		result := NewResultExport(m)
+		coverage := float64(result.NodesExplored) / float64(m.GetEmptySquares())
+		row := []string{
+			timestamp,
+			name,
+			string(result.Algorithm),
+			strconv.FormatBool(result.Solved),
+			strconv.Itoa(result.PathLength),
+			strconv.Itoa(result.PathCost),
+			strconv.Itoa(result.NodesExplored),
+			strconv.FormatFloat(coverage, 'f', 4, 64),
+			strconv.Itoa(result.FrontierPeak),
+			strconv.FormatFloat(result.TimeSeconds, 'f', 6, 64),
+			strconv.Itoa(result.Expanded),
+			strconv.Itoa(result.Generated),
+			strconv.FormatUint(result.BytesAlloc, 10),
+			strconv.FormatUint(result.Allocs, 10),
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,176 @@
+package render
+
+import (
+	"bufio"
+	"compress/lzw"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+)
+
+// A GIF encoder which writes every frame to the underlying writer as soon as it is added, instead of keeping all
+// frames in memory like gif.EncodeAll does. Every frame shares the global color table and the transparent index
+type gifWriter struct {
+	w           *bufio.Writer
+	palette     color.Palette
+	transparent int // Index of the transparent color, -1 if the palette has none
+	litWidth    int // Minimum LZW code size
+	err         error
+}
+
+// Write the GIF header, global color table and the loop extension into w. transparent is the index of the palette
+// color drawn as transparent, -1 for none
+func newGIFWriter(w io.Writer, width, height int, palette color.Palette, transparent int) (*gifWriter, error) {
+	if len(palette) == 0 || len(palette) > 256 {
+		return nil, fmt.Errorf("GIF palette must have 1 to 256 colors, got %d", len(palette))
+	}
+	if width <= 0 || height <= 0 || width > 0xffff || height > 0xffff {
+		return nil, fmt.Errorf("GIF size %dx%d is out of range", width, height)
+	}
+	if transparent < -1 || transparent >= len(palette) {
+		return nil, fmt.Errorf("GIF transparent index %d is out of the palette of %d colors", transparent, len(palette))
+	}
+
+	// The color table size is a power of 2, at least 2 colors
+	tableBits := max(bits.Len(uint(len(palette)-1)), 1)
+
+	g := &gifWriter{
+		w:           bufio.NewWriter(w),
+		palette:     palette,
+		transparent: transparent,
+		litWidth:    max(tableBits, 2),
+	}
+
+	// Header and logical screen descriptor
+	g.w.WriteString("GIF89a")
+	g.writeUint16(width)
+	g.writeUint16(height)
+	g.w.WriteByte(0x80 | byte(tableBits-1)) // Global color table flag and its size
+	g.w.WriteByte(0)                        // Background color index
+	g.w.WriteByte(0)                        // Pixel aspect ratio
+
+	// Global color table, padded with black
+	for i := range 1 << tableBits {
+		var r, gr, b uint32
+		if i < len(palette) {
+			r, gr, b, _ = palette[i].RGBA()
+		}
+		g.w.Write([]byte{byte(r >> 8), byte(gr >> 8), byte(b >> 8)})
+	}
+
+	// Application extension to loop forever
+	g.w.Write([]byte{0x21, 0xff, 0x0b})
+	g.w.WriteString("NETSCAPE2.0")
+	g.w.Write([]byte{0x03, 0x01, 0x00, 0x00, 0x00})
+
+	return g, g.flush()
+}
+
+// Encode a frame and write it out. The frame must use the palette of the writer and fit inside the GIF.
+// Delay is in 100ths of a second
+func (g *gifWriter) WriteFrame(img *image.Paletted, delay int, disposal byte) error {
+	if g.err != nil {
+		return g.err
+	}
+
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil
+	}
+
+	// Graphic control extension
+	flags := disposal << 2
+	transparent := byte(0)
+	if g.transparent >= 0 {
+		flags |= 0x01
+		transparent = byte(g.transparent)
+	}
+	g.w.Write([]byte{0x21, 0xf9, 0x04, flags})
+	g.writeUint16(delay)
+	g.w.Write([]byte{transparent, 0x00})
+
+	// Image descriptor, without local color table
+	g.w.WriteByte(0x2c)
+	g.writeUint16(bounds.Min.X)
+	g.writeUint16(bounds.Min.Y)
+	g.writeUint16(bounds.Dx())
+	g.writeUint16(bounds.Dy())
+	g.w.WriteByte(0)
+
+	// Image data, LZW compressed and split into sub-blocks
+	g.w.WriteByte(byte(g.litWidth))
+	blocks := &gifBlockWriter{w: g.w}
+	compressor := lzw.NewWriter(blocks, lzw.LSB, g.litWidth)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		start := img.PixOffset(bounds.Min.X, y)
+		if _, err := compressor.Write(img.Pix[start : start+bounds.Dx()]); err != nil {
+			g.err = err
+			return err
+		}
+	}
+	if err := compressor.Close(); err != nil {
+		g.err = err
+		return err
+	}
+	blocks.close()
+
+	return g.flush()
+}
+
+// Write the GIF trailer. The underlying writer is not closed
+func (g *gifWriter) Close() error {
+	if g.err != nil {
+		return g.err
+	}
+
+	g.w.WriteByte(0x3b)
+	return g.flush()
+}
+
+func (g *gifWriter) writeUint16(v int) {
+	g.w.Write([]byte{byte(v), byte(v >> 8)})
+}
+
+func (g *gifWriter) flush() error {
+	if g.err == nil {
+		g.err = g.w.Flush()
+	}
+	return g.err
+}
+
+// Split the compressed data into the sub-blocks of at most 255 bytes GIF expects
+type gifBlockWriter struct {
+	w   *bufio.Writer
+	buf [255]byte
+	n   int
+}
+
+func (b *gifBlockWriter) Write(data []byte) (int, error) {
+	for i := range data {
+		b.buf[b.n] = data[i]
+		b.n++
+		if b.n == len(b.buf) {
+			b.flushBlock()
+		}
+	}
+
+	return len(data), nil
+}
+
+func (b *gifBlockWriter) flushBlock() {
+	if b.n == 0 {
+		return
+	}
+
+	b.w.WriteByte(byte(b.n))
+	b.w.Write(b.buf[:b.n])
+	b.n = 0
+}
+
+// Flush the remaining data and write the block terminator
+func (b *gifBlockWriter) close() {
+	b.flushBlock()
+	b.w.WriteByte(0)
+}
@@ -0,0 +1,72 @@
+package render_test
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// A theme file can't take the transparent color of the GIF frames
+func TestLoadThemeRefusesTransparentColor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(path, []byte(`{"background": "#ffffff00"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := render.LoadTheme(path); err == nil {
+		t.Fatal("a fully transparent theme color is accepted")
+	}
+}
+
+// The GIF draws the unchanged pixels of its frames with the color added after the theme, even when a color of the
+// theme is transparent too
+func TestWriteGIFTransparentIndex(t *testing.T) {
+	m := &maze.Maze{}
+	if err := m.Load("A 3\n# B"); err != nil {
+		t.Fatal(err)
+	}
+	solver, err := solve.NewSolverForAlgo(maze.BFS, m, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := solver.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := render.DefaultRenderOptions()
+	opts.CellSize = 4
+	opts.Theme.Background = color.RGBA{}
+	var buf bytes.Buffer
+	if err := render.WriteGIF(&buf, &maze.Solved{Maze: m, SearchType: maze.BFS, Result: result}, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) < 2 {
+		t.Fatalf("%d frames, expected the full frame and delta frames", len(anim.Image))
+	}
+
+	// The decoder makes the transparent index of a frame a zero color
+	for i, frame := range anim.Image {
+		var transparent []int
+		for j, c := range frame.Palette {
+			if _, _, _, a := c.RGBA(); a == 0 {
+				transparent = append(transparent, j)
+			}
+		}
+		if len(transparent) != 1 || transparent[0] == 0 {
+			t.Fatalf("frame %d: colors %v are transparent, expected only the one added after the theme", i, transparent)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	legendPadding    = 6
+	legendLineHeight = 16 // basicfont.Face7x13 is 13px high, plus a little spacing
+	legendCharWidth  = 7
+	legendSwatch     = 10 // Size of the color key
+	legendMinWidth   = 200
+	counterMinWidth  = 300 // Enough for "step: 99999  frontier: 9999  explored: 99999"
+)
+
+// One color key of the legend
+type legendItem struct {
+	Label string
+	Index uint8 // Palette index
+}
+
+var legendItems = []legendItem{
+	{"start", 2},
+	{"goal", 3},
+	{"visited", 4},
+	{"path", 6},
+	{"weighted", 8},
+}
+
+// Width (in pixel) of a legend item: swatch, space, label and the gap to the next item
+func (item legendItem) width() int {
+	return legendSwatch + 4 + len(item.Label)*legendCharWidth + 2*legendPadding
+}
+
+// Get the size of the maze part of the image
+func (opts RenderOptions) mazeSize(m *maze.Solved) (int, int) {
+	return m.Width*opts.CellSize + 2*opts.BorderWidth, m.Height*opts.CellSize + 2*opts.BorderWidth
+}
+
+// Get the size of the whole image, which is the maze plus the legend strip and stats banner underneath if enabled
+func (opts RenderOptions) canvasSize(m *maze.Solved) (int, int) {
+	width, height := opts.mazeSize(m)
+	if !opts.Legend {
+		return width, height
+	}
+
+	width = max(width, legendMinWidth)
+	keys, stats := legendLayout(m, opts, width)
+	return width, height + (len(keys)+len(stats))*legendLineHeight + 2*legendPadding
+}
+
+// Split the legend items and the stats banner into lines that fit the width of the image
+func legendLayout(m *maze.Solved, opts RenderOptions, width int) ([][]legendItem, []string) {
+	available := width - 2*legendPadding
+
+	var keys [][]legendItem
+	var line []legendItem
+	used := 0
+	for _, item := range legendItems {
+		// Explored squares are not drawn in solution-only mode
+		if opts.SolutionOnly && item.Index == 4 {
+			continue
+		}
+
+		if len(line) > 0 && used+item.width() > available {
+			keys = append(keys, line)
+			line, used = nil, 0
+		}
+		line = append(line, item)
+		used += item.width()
+	}
+	keys = append(keys, line)
+
+	path := fmt.Sprintf("path: %d", m.PathLength)
+	if m.Unreachable {
+		path = "path: none, goal unreachable"
+	}
+	parts := []string{
+		string(m.SearchType),
+		path,
+		fmt.Sprintf("cost: %d", m.PathCost),
+		fmt.Sprintf("explored: %d", len(m.Explored)),
+		fmt.Sprintf("time: %.3fms", float64(m.SolveTime.Microseconds())/1000),
+	}
+
+	var stats []string
+	text := ""
+	for _, part := range parts {
+		if text != "" && (len(text)+2+len(part))*legendCharWidth > available {
+			stats = append(stats, text)
+			text = ""
+		}
+		if text != "" {
+			text += "  "
+		}
+		text += part
+	}
+	stats = append(stats, text)
+
+	return keys, stats
+}
+
+// Get the rectangle of the stats banner
+func (opts RenderOptions) statsRect(m *maze.Solved) image.Rectangle {
+	width, height := opts.canvasSize(m)
+	_, mazeHeight := opts.mazeSize(m)
+	keys, _ := legendLayout(m, opts, width)
+	return image.Rect(0, mazeHeight+legendPadding+len(keys)*legendLineHeight, width, height)
+}
+
+// Draw the legend strip under the maze, and the stats banner under it if withStats is set
+func drawLegend(img draw.Image, m *maze.Solved, opts RenderOptions, palette color.Palette, withStats bool) {
+	if !opts.Legend {
+		return
+	}
+
+	width, _ := opts.canvasSize(m)
+	_, top := opts.mazeSize(m)
+	keys, stats := legendLayout(m, opts, width)
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(palette[1]),
+		Face: basicfont.Face7x13,
+	}
+
+	y := top + legendPadding
+	for _, line := range keys {
+		x := legendPadding
+		for _, item := range line {
+			swatch := image.Rect(x, y+2, x+legendSwatch, y+2+legendSwatch)
+			draw.Draw(img, swatch, &image.Uniform{palette[item.Index]}, image.Point{}, draw.Src)
+
+			drawer.Dot = fixed.P(x+legendSwatch+4, y+legendSwatch+1)
+			drawer.DrawString(item.Label)
+			x += item.width()
+		}
+		y += legendLineHeight
+	}
+
+	if !withStats {
+		return
+	}
+
+	for _, line := range stats {
+		drawer.Dot = fixed.P(legendPadding, y+legendSwatch+1)
+		drawer.DrawString(strings.TrimSpace(line))
+		y += legendLineHeight
+	}
+}
+
+// Draw the solver counters of a step into rect, replacing what was there
+func drawCounters(img draw.Image, rect image.Rectangle, palette color.Palette, step int, counter maze.StepCounter) {
+	draw.Draw(img, rect, &image.Uniform{palette[0]}, image.Point{}, draw.Src)
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(palette[1]),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(rect.Min.X+legendPadding, rect.Min.Y+legendPadding+legendSwatch+1),
+	}
+	drawer.DrawString(fmt.Sprintf("step: %d  frontier: %d  explored: %d", step, counter.Frontier, counter.Explored))
+}
+
+// Print the expansion index at the bottom right of each explored square, like how search is illustrated in textbooks.
+// Nothing is drawn if the largest index doesn't fit in a square
+func drawExpansionOrder(img draw.Image, m *maze.Solved, opts RenderOptions, palette color.Palette) {
+	if !opts.ExpansionOrder || opts.SolutionOnly || len(m.SearchTree) == 0 {
+		return
+	}
+
+	digits := len(fmt.Sprint(len(m.SearchTree) - 1))
+	if digits*legendCharWidth+2 > opts.CellSize || opts.CellSize < 13 {
+		return
+	}
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(palette[9]),
+		Face: basicfont.Face7x13,
+	}
+
+	for i, node := range m.SearchTree {
+		label := fmt.Sprint(i)
+		rect := opts.cellRect(node.Square.Coordinate.Row, node.Square.Coordinate.Col)
+		drawer.Dot = fixed.P(rect.Max.X-1-len(label)*legendCharWidth, rect.Max.Y-2)
+		drawer.DrawString(label)
+	}
+}
@@ -0,0 +1,115 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+const (
+	liveMinDelay = time.Millisecond
+	liveMaxDelay = 2 * time.Second
+)
+
+// A terminal viewer which animates the solving as it happens. It hooks into the solver steps and redraws the maze
+// in text after each of them, waiting between steps so the solving can be followed
+type LiveView struct {
+	out    io.Writer
+	style  TextStyle
+	mu     sync.Mutex
+	delay  time.Duration // Wait after each step
+	paused bool
+}
+
+// Create a live view which draws into out
+func NewLiveView(out io.Writer, style TextStyle, delay time.Duration) *LiveView {
+	return &LiveView{
+		out:   out,
+		style: style,
+		delay: min(max(delay, liveMinDelay), liveMaxDelay),
+	}
+}
+
+// Get the step hook which animates the solving of the maze, to be set on the solver before solving
+func (v *LiveView) Hook(m *maze.Maze, algo maze.Algo) maze.StepHook {
+	// The solver only reports its steps, so the progress is rebuilt from them
+	progress := &maze.Solved{Maze: m, SearchType: algo}
+	seen := make(map[maze.Point]bool)
+	return func(step int, p maze.Point, counter maze.StepCounter) {
+		if !seen[p] {
+			seen[p] = true
+			progress.Explored = append(progress.Explored, p)
+		}
+
+		v.draw(progress, &p, step, counter)
+		v.wait()
+	}
+}
+
+// Draw the final state of the solved maze, with its solution
+func (v *LiveView) Finish(m *maze.Solved) {
+	var counter maze.StepCounter
+	if len(m.Counters) > 0 {
+		counter = m.Counters[len(m.Counters)-1]
+	}
+	v.draw(m, nil, len(m.ExperimentPath), counter)
+}
+
+// Halve the delay between steps
+func (v *LiveView) Faster() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.delay = max(v.delay/2, liveMinDelay)
+}
+
+// Double the delay between steps
+func (v *LiveView) Slower() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.delay = min(v.delay*2, liveMaxDelay)
+}
+
+// Pause or resume the solving
+func (v *LiveView) TogglePause() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.paused = !v.paused
+}
+
+// Clear the terminal and draw the maze, with the cursor if not nil
+func (v *LiveView) draw(m *maze.Solved, cursor *maze.Point, step int, counter maze.StepCounter) {
+	symbols := textSymbols(m, v.style, true)
+	if cursor != nil && *cursor != m.Start && *cursor != m.Goal {
+		symbols[cursor.Row][cursor.Col] = v.style.Cursor
+	}
+
+	v.mu.Lock()
+	delay, paused := v.delay, v.paused
+	v.mu.Unlock()
+
+	status := fmt.Sprintf("delay: %v", delay)
+	if paused {
+		status = "paused"
+	}
+
+	// Move the cursor home and clear the screen, then draw the whole frame at once to avoid flickering
+	fmt.Fprintf(v.out, "\033[H\033[2J%s\n%s  step: %d  frontier: %d  explored: %d  (%s)\n[+] faster  [-] slower  [space] pause\n",
+		joinSymbols(symbols), m.SearchType, step, counter.Frontier, counter.Explored, status)
+}
+
+// Wait the delay, and for as long as the view is paused
+func (v *LiveView) wait() {
+	for {
+		v.mu.Lock()
+		delay, paused := v.delay, v.paused
+		v.mu.Unlock()
+
+		time.Sleep(delay)
+		if !paused {
+			return
+		}
+	}
+}
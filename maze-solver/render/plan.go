@@ -0,0 +1,34 @@
+package render
+
+import "github.com/danglnh07/go-ai/maze-solver/maze"
+
+// The sizes of the images of a maze, computed before solving it
+type Plan struct {
+	CellSize    int // After the pixel budget
+	ImageWidth  int // Of the solution image
+	ImageHeight int
+	GIFWidth    int
+	GIFHeight   int
+	GIFFrames   int   // At most, after the frame stride, MaxFrames and the GIF memory budget
+	GIFBytes    int64 // Estimated memory of the GIF, as frames x frame size
+}
+
+// Plan the images of m for a solve of at most steps steps, without drawing anything. An error is returned when the GIF
+// wouldn't fit the memory budget
+func PlanImages(m *maze.Maze, steps int, opts RenderOptions) (Plan, error) {
+	solved := &maze.Solved{Maze: m}
+	opts = opts.fit(solved)
+
+	plan := Plan{CellSize: opts.CellSize}
+	plan.ImageWidth, plan.ImageHeight = opts.canvasSize(solved)
+	plan.GIFWidth, plan.GIFHeight, _ = opts.gifLayout(solved)
+
+	budgeted, err := opts.fitGIFMemory(steps, plan.GIFWidth, plan.GIFHeight)
+	if err != nil {
+		return plan, err
+	}
+
+	plan.GIFFrames = gifFrames(steps, budgeted.frameStride(steps), true)
+	plan.GIFBytes = int64(plan.GIFFrames) * int64(plan.GIFWidth) * int64(plan.GIFHeight)
+	return plan, nil
+}
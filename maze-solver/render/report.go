@@ -0,0 +1,118 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// One algorithm section of the HTML report
+type reportEntry struct {
+	Algo     maze.Algo
+	Length   int
+	Cost     int
+	Explored int
+	Coverage string
+	Time     time.Duration
+	Image    template.URL // PNG as data URI
+	GIF      template.URL // GIF as data URI, empty if GIF is not included
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Maze solving report: {{.Name}}</title>
+<style>
+	body { font-family: sans-serif; margin: 2em; color: #222; }
+	table { border-collapse: collapse; margin-bottom: 2em; }
+	th, td { border: 1px solid #ccc; padding: 4px 12px; text-align: right; }
+	th:first-child, td:first-child { text-align: left; }
+	pre { background: #f4f4f4; padding: 1em; display: inline-block; line-height: 1; }
+	.algo { display: inline-block; vertical-align: top; margin: 0 2em 2em 0; }
+	.algo img { display: block; margin-bottom: 0.5em; image-rendering: pixelated; }
+</style>
+</head>
+<body>
+<h1>Maze solving report</h1>
+<p>Maze <code>{{.Name}}</code>, {{.Width}}&times;{{.Height}}, {{.Empty}} empty squares. Generated at {{.Generated}}.</p>
+<pre>{{.Maze}}</pre>
+
+<h2>Statistics</h2>
+<table>
+<tr><th>Algorithm</th><th>Path length</th><th>Path cost</th><th>Nodes explored</th><th>Coverage</th><th>Time</th></tr>
+{{range .Entries}}<tr><td>{{.Algo}}</td><td>{{.Length}}</td><td>{{.Cost}}</td><td>{{.Explored}}</td><td>{{.Coverage}}</td><td>{{.Time}}</td></tr>
+{{end}}</table>
+
+<h2>Results</h2>
+{{range .Entries}}<div class="algo">
+<h3>{{.Algo}}</h3>
+<img src="{{.Image}}" alt="{{.Algo}} solution">
+{{if .GIF}}<img src="{{.GIF}}" alt="{{.Algo}} animation">{{end}}
+</div>
+{{end}}
+</body>
+</html>
+`))
+
+// Create a self-contained HTML report, which embeds the maze, the solution image (and animation if withGIF is set)
+// of every algorithm and a statistics table into a single file.
+// Every maze should be the same maze solved by a different algorithm, and 'raw' is the text of the maze.
+func CreateHTMLReport(name, raw string, mazes []*maze.Solved, opts RenderOptions, withGIF bool) (*bytes.Buffer, error) {
+	if len(mazes) == 0 {
+		return nil, fmt.Errorf("no maze to put into report")
+	}
+
+	var entries []reportEntry
+	for _, m := range mazes {
+		img, err := CreateSolutionImage(m, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := reportEntry{
+			Algo:     m.SearchType,
+			Length:   m.PathLength,
+			Cost:     m.PathCost,
+			Explored: len(m.Explored),
+			Coverage: fmt.Sprintf("%.2f%%", 100*float64(len(m.Explored))/float64(m.GetEmptySquares())),
+			Time:     m.SolveTime,
+			Image:    dataURI("image/png", img.Bytes()),
+		}
+
+		if withGIF {
+			buf, err := CreateGIF(m, opts)
+			if err != nil {
+				return nil, err
+			}
+			entry.GIF = dataURI("image/gif", buf.Bytes())
+		}
+
+		entries = append(entries, entry)
+	}
+
+	buf := new(bytes.Buffer)
+	err := reportTemplate.Execute(buf, map[string]any{
+		"Name":      name,
+		"Maze":      raw,
+		"Width":     mazes[0].Width,
+		"Height":    mazes[0].Height,
+		"Empty":     mazes[0].GetEmptySquares(),
+		"Generated": maze.Now().Format(time.RFC1123),
+		"Entries":   entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute report template: %v", err)
+	}
+
+	return buf, nil
+}
+
+// Encode data as a base64 data URI
+func dataURI(mime string, data []byte) template.URL {
+	return template.URL("data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data))
+}
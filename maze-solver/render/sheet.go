@@ -0,0 +1,78 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	sheetPadding    = 10  // Space (in pixel) around and between tiles
+	sheetLineHeight = 15  // basicfont.Face7x13 is 13px high, plus a little spacing
+	sheetMinTile    = 180 // Minimum tile width, so the caption always fits
+)
+
+// Create a single PNG which tiles the solution image of every maze side by side, with the algorithm name and key stats
+// (path length, nodes explored, solving time) captioned underneath each tile.
+// Every maze should be the same maze solved by a different algorithm.
+func CreateContactSheet(mazes []*maze.Solved, opts RenderOptions) (*bytes.Buffer, error) {
+	if len(mazes) == 0 {
+		return nil, fmt.Errorf("no maze to put into contact sheet")
+	}
+
+	// Render every tile first, so we know how big the sheet should be
+	tiles := make([]image.Image, len(mazes))
+	captions := make([][]string, len(mazes))
+	tileWidth, tileHeight, captionLines := sheetMinTile, 0, 0
+	for i, m := range mazes {
+		tiles[i] = renderSolution(m, opts)
+		tileWidth = max(tileWidth, tiles[i].Bounds().Dx())
+		tileHeight = max(tileHeight, tiles[i].Bounds().Dy())
+
+		captions[i] = []string{
+			string(m.SearchType),
+			fmt.Sprintf("path: %d (cost %d)", m.PathLength, m.PathCost),
+			fmt.Sprintf("explored: %d", len(m.Explored)),
+			fmt.Sprintf("time: %.3fms", float64(m.SolveTime.Microseconds())/1000), // basicfont has no 'µ'
+		}
+		captionLines = max(captionLines, len(captions[i]))
+	}
+
+	width := len(mazes)*(tileWidth+sheetPadding) + sheetPadding
+	height := tileHeight + captionLines*sheetLineHeight + 3*sheetPadding
+	sheet := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(sheet, sheet.Bounds(), &image.Uniform{opts.Theme.Background}, image.Point{}, draw.Src)
+
+	for i, tile := range tiles {
+		// Draw the tile
+		x := sheetPadding + i*(tileWidth+sheetPadding)
+		rect := image.Rect(x, sheetPadding, x+tile.Bounds().Dx(), sheetPadding+tile.Bounds().Dy())
+		draw.Draw(sheet, rect, tile, image.Point{}, draw.Src)
+
+		// Draw the caption under the tile
+		drawer := &font.Drawer{
+			Dst:  sheet,
+			Src:  image.NewUniform(opts.Theme.Wall),
+			Face: basicfont.Face7x13,
+		}
+		for j, line := range captions[i] {
+			y := tileHeight + 2*sheetPadding + (j+1)*sheetLineHeight
+			drawer.Dot = fixed.P(x, y)
+			drawer.DrawString(line)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, sheet); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %v", err)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Create the solution image as SVG, which stays sharp at any zoom and is small for big mazes. It draws the maze, the
+// explored squares (unless SolutionOnly), the solution path and the costs of the weighted squares with the colors of
+// the theme. The grid lines, expansion order and legend are only drawn in the PNG
+func CreateSVG(m *maze.Solved, opts RenderOptions) (*bytes.Buffer, error) {
+	palette := opts.palette()
+	width, height := opts.mazeSize(m)
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width, height, width, height)
+	if !opts.Transparent {
+		fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, hexColor(palette[0]))
+	}
+
+	// The walls of a row are merged into runs, which keeps the file small
+	for row := range m.Height {
+		for col := 0; col < m.Width; col++ {
+			if !m.Square(maze.Point{Row: row, Col: col}).IsWall {
+				continue
+			}
+
+			end := col
+			for end+1 < m.Width && m.Square(maze.Point{Row: row, Col: end + 1}).IsWall {
+				end++
+			}
+			svgRect(buf, opts.cellRect(row, col).Union(opts.cellRect(row, end)), palette[1])
+			col = end
+		}
+	}
+	for row := range m.Height {
+		for col := range m.Width {
+			if sq := m.Square(maze.Point{Row: row, Col: col}); !sq.IsWall && sq.Cost > 1 {
+				svgRect(buf, opts.cellRect(row, col), palette[8])
+			}
+		}
+	}
+
+	if !opts.SolutionOnly {
+		for _, p := range m.Explored {
+			svgRect(buf, opts.cellRect(p.Row, p.Col), palette[4])
+		}
+	}
+
+	pathColors := opts.pathColorIndexes(m)
+	for i, p := range m.Solution.Path {
+		svgRect(buf, opts.cellRect(p.Row, p.Col), palette[pathColors[i]])
+	}
+	start, goal := opts.endRects(m.Maze)
+	svgRect(buf, start, palette[2])
+	svgRect(buf, goal, palette[3])
+	if m.Unreachable {
+		r := opts.cellRect(m.Goal.Row, m.Goal.Col)
+		fmt.Fprintf(buf, `<path d="M%d %dL%d %dM%d %dL%d %d" stroke="%s" stroke-width="%d"/>`+"\n", r.Min.X, r.Min.Y, r.Max.X, r.Max.Y,
+			r.Max.X, r.Min.Y, r.Min.X, r.Max.Y, hexColor(palette[1]), max(opts.CellSize/8, 1))
+	}
+
+	// The costs are written on top of everything, like in the PNG
+	for row := range m.Height {
+		for col := range m.Width {
+			if sq := m.Square(maze.Point{Row: row, Col: col}); !sq.IsWall && sq.Cost > 1 {
+				r := opts.cellRect(row, col)
+				fmt.Fprintf(buf, `<text x="%d" y="%d" font-family="monospace" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%d</text>`+"\n",
+					(r.Min.X+r.Max.X)/2, (r.Min.Y+r.Max.Y)/2, max(opts.CellSize*2/3, 1), hexColor(palette[9]), sq.Cost)
+			}
+		}
+	}
+
+	buf.WriteString("</svg>\n")
+	return buf, nil
+}
+
+// Write a filled rectangle
+func svgRect(buf *bytes.Buffer, r image.Rectangle, c color.Color) {
+	fmt.Fprintf(buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", r.Min.X, r.Min.Y, r.Dx(), r.Dy(), hexColor(c))
+}
+
+// Get a color as #rrggbb, the alpha is ignored
+func hexColor(c color.Color) string {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x", nrgba.R, nrgba.G, nrgba.B)
+}
@@ -0,0 +1,119 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Symbols used to draw a maze as text, one symbol per square
+type TextStyle struct {
+	Name     string
+	Wall     string
+	Empty    string
+	Start    string
+	Goal     string
+	Path     string
+	Explored string
+	Cursor   string // The square the solver is at, only used by the live view
+	Weighted string // Empty means the cost digit of the square is printed
+}
+
+var (
+	// Plain ASCII, same symbols as the maze input
+	ASCIIStyle = TextStyle{
+		Name:     "ascii",
+		Wall:     "#",
+		Empty:    " ",
+		Start:    "A",
+		Goal:     "B",
+		Path:     "*",
+		Explored: ".",
+		Cursor:   "@",
+	}
+
+	// Emoji squares, for sharing in chat apps
+	EmojiStyle = TextStyle{
+		Name:     "emoji",
+		Wall:     "⬛",
+		Empty:    "⬜",
+		Start:    "🟩",
+		Goal:     "🟥",
+		Path:     "🟪",
+		Explored: "🟦",
+		Cursor:   "🟨",
+		Weighted: "🟧",
+	}
+
+	textStyles = map[string]TextStyle{
+		ASCIIStyle.Name: ASCIIStyle,
+		EmojiStyle.Name: EmojiStyle,
+	}
+)
+
+// Get a text style by its name
+func LoadTextStyle(name string) (TextStyle, error) {
+	style, ok := textStyles[strings.ToLower(name)]
+	if !ok {
+		return TextStyle{}, fmt.Errorf("unknown text style %q", name)
+	}
+
+	return style, nil
+}
+
+// Draw the solved maze as text with the given style. The explored squares are only drawn if withExplored is set
+func RenderText(m *maze.Solved, style TextStyle, withExplored bool) string {
+	return joinSymbols(textSymbols(m, style, withExplored))
+}
+
+// Get the symbol of every square of the solved maze
+func textSymbols(m *maze.Solved, style TextStyle, withExplored bool) [][]string {
+	symbols := make([][]string, m.Height)
+	for row := range m.Height {
+		symbols[row] = make([]string, m.Width)
+		for col := range m.Width {
+			switch sq := m.Square(maze.Point{Row: row, Col: col}); {
+			case sq.IsWall:
+				symbols[row][col] = style.Wall
+			case sq.Cost > 1 && style.Weighted == "":
+				symbols[row][col] = strconv.Itoa(sq.Cost)
+			case sq.Cost > 1:
+				symbols[row][col] = style.Weighted
+			default:
+				symbols[row][col] = style.Empty
+			}
+		}
+	}
+
+	if withExplored {
+		for _, p := range m.Explored {
+			// Keep the cost of weighted squares visible, like in images
+			if m.Cost(p) > 1 {
+				continue
+			}
+			symbols[p.Row][p.Col] = style.Explored
+		}
+	}
+
+	for _, p := range m.Solution.Path {
+		symbols[p.Row][p.Col] = style.Path
+	}
+
+	symbols[m.Start.Row][m.Start.Col] = style.Start
+	symbols[m.Goal.Row][m.Goal.Col] = style.Goal
+
+	return symbols
+}
+
+// Join the symbols into lines of text
+func joinSymbols(symbols [][]string) string {
+	var builder strings.Builder
+	for _, line := range symbols {
+		builder.WriteString(strings.Join(line, ""))
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,199 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+)
+
+// Theme is the set of colors used when rendering the maze into image and GIF
+type Theme struct {
+	Name       string
+	Background color.RGBA // Empty squares and image background
+	Wall       color.RGBA
+	Start      color.RGBA
+	Goal       color.RGBA
+	Visited    color.RGBA // Explored squares
+	Cursor     color.RGBA // The square the solver is currently on (GIF only)
+	Path       color.RGBA // Solution path
+	PathEnd    color.RGBA // End of the solution path gradient on weighted mazes
+	Border     color.RGBA
+	Weighted   color.RGBA // Squares with cost > 1
+	Text       color.RGBA // Cost label drawn on top of weighted squares
+	Grid       color.RGBA // Lines between squares, when enabled
+	Frontier   color.RGBA // Squares waiting in the frontier (GIF only)
+}
+
+// Built-in themes
+var (
+	LightTheme = Theme{
+		Name:       "light",
+		Background: color.RGBA{255, 255, 255, 255},
+		Wall:       color.RGBA{0, 0, 0, 255},
+		Start:      color.RGBA{0, 255, 0, 255},
+		Goal:       color.RGBA{255, 0, 0, 255},
+		Visited:    color.RGBA{128, 128, 128, 255},
+		Cursor:     color.RGBA{255, 255, 0, 255},
+		Path:       color.RGBA{255, 0, 255, 255},
+		PathEnd:    color.RGBA{75, 0, 130, 255},
+		Border:     color.RGBA{0, 0, 255, 255},
+		Weighted:   color.RGBA{255, 165, 0, 255},
+		Text:       color.RGBA{0, 0, 0, 255},
+		Grid:       color.RGBA{200, 200, 200, 255},
+		Frontier:   color.RGBA{100, 180, 255, 255},
+	}
+
+	DarkTheme = Theme{
+		Name:       "dark",
+		Background: color.RGBA{24, 24, 27, 255},
+		Wall:       color.RGBA{113, 113, 122, 255},
+		Start:      color.RGBA{34, 197, 94, 255},
+		Goal:       color.RGBA{239, 68, 68, 255},
+		Visited:    color.RGBA{51, 65, 85, 255},
+		Cursor:     color.RGBA{250, 204, 21, 255},
+		Path:       color.RGBA{217, 70, 239, 255},
+		PathEnd:    color.RGBA{254, 240, 138, 255},
+		Border:     color.RGBA{59, 130, 246, 255},
+		Weighted:   color.RGBA{249, 115, 22, 255},
+		Text:       color.RGBA{0, 0, 0, 255},
+		Grid:       color.RGBA{63, 63, 70, 255},
+		Frontier:   color.RGBA{8, 145, 178, 255},
+	}
+
+	// Based on the Okabe-Ito palette, which stays distinguishable for the common forms of color blindness
+	ColorblindTheme = Theme{
+		Name:       "colorblind",
+		Background: color.RGBA{255, 255, 255, 255},
+		Wall:       color.RGBA{0, 0, 0, 255},
+		Start:      color.RGBA{0, 158, 115, 255},
+		Goal:       color.RGBA{213, 94, 0, 255},
+		Visited:    color.RGBA{190, 190, 190, 255},
+		Cursor:     color.RGBA{240, 228, 66, 255},
+		Path:       color.RGBA{0, 114, 178, 255},
+		PathEnd:    color.RGBA{204, 121, 167, 255},
+		Border:     color.RGBA{86, 180, 233, 255},
+		Weighted:   color.RGBA{230, 159, 0, 255},
+		Text:       color.RGBA{0, 0, 0, 255},
+		Grid:       color.RGBA{220, 220, 220, 255},
+		Frontier:   color.RGBA{153, 204, 238, 255},
+	}
+
+	themes = map[string]Theme{
+		LightTheme.Name:      LightTheme,
+		DarkTheme.Name:       DarkTheme,
+		ColorblindTheme.Name: ColorblindTheme,
+	}
+)
+
+// Build the GIF palette from the theme. The index of each color is fixed, since the renderers refer to them by index:
+// 0: background, 1: wall, 2: start, 3: goal, 4: visited, 5: cursor, 6: path, 7: border, 8: weighted, 9: text,
+// 10: grid, 11: frontier
+func (t Theme) Palette() color.Palette {
+	return color.Palette{
+		t.Background,
+		t.Wall,
+		t.Start,
+		t.Goal,
+		t.Visited,
+		t.Cursor,
+		t.Path,
+		t.Border,
+		t.Weighted,
+		t.Text,
+		t.Grid,
+		t.Frontier,
+	}
+}
+
+// Get a theme by its name (light, dark, colorblind), or load a custom theme from a JSON file.
+// An empty name returns the light theme.
+func LoadTheme(name string) (Theme, error) {
+	if name == "" {
+		return LightTheme, nil
+	}
+
+	if theme, ok := themes[name]; ok {
+		return theme, nil
+	}
+
+	if strings.HasSuffix(name, ".json") {
+		return loadThemeFile(name)
+	}
+
+	return Theme{}, fmt.Errorf("unknown theme %q", name)
+}
+
+// Load a custom theme from JSON file. The file is an object of hex colors, for example:
+// {"background": "#ffffff", "wall": "#000000", "path": "#ff00ff"}.
+// Any color not specified falls back to the light theme.
+func loadThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	var colors map[string]string
+	if err := json.Unmarshal(data, &colors); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme file: %v", err)
+	}
+
+	theme := LightTheme
+	theme.Name = path
+	fields := map[string]*color.RGBA{
+		"background": &theme.Background,
+		"wall":       &theme.Wall,
+		"start":      &theme.Start,
+		"goal":       &theme.Goal,
+		"visited":    &theme.Visited,
+		"cursor":     &theme.Cursor,
+		"path":       &theme.Path,
+		"path_end":   &theme.PathEnd,
+		"border":     &theme.Border,
+		"weighted":   &theme.Weighted,
+		"text":       &theme.Text,
+		"grid":       &theme.Grid,
+		"frontier":   &theme.Frontier,
+	}
+
+	for key, hex := range colors {
+		field, ok := fields[strings.ToLower(key)]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme color %q", key)
+		}
+
+		c, err := parseHexColor(hex)
+		if err != nil {
+			return Theme{}, fmt.Errorf("invalid color for %q: %v", key, err)
+		}
+		// The transparent color of the GIFs is reserved for the pixels unchanged since the previous frame
+		if c.A == 0 {
+			return Theme{}, fmt.Errorf("invalid color for %q: %q is fully transparent", key, hex)
+		}
+		*field = c
+	}
+
+	return theme, nil
+}
+
+// Parse color in the form of #rrggbb or #rrggbbaa
+func parseHexColor(hex string) (color.RGBA, error) {
+	hex = strings.TrimPrefix(hex, "#")
+	c := color.RGBA{A: 255}
+
+	switch len(hex) {
+	case 6:
+		if _, err := fmt.Sscanf(hex, "%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+			return c, err
+		}
+	case 8:
+		if _, err := fmt.Sscanf(hex, "%02x%02x%02x%02x", &c.R, &c.G, &c.B, &c.A); err != nil {
+			return c, err
+		}
+	default:
+		return c, fmt.Errorf("expect #rrggbb or #rrggbbaa, got %q", hex)
+	}
+
+	return c, nil
+}
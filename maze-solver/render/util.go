@@ -0,0 +1,675 @@
+// Package render draws solved mazes into images, GIFs, text and reports.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Options used when rendering the maze into image or GIF
+type RenderOptions struct {
+	Theme         Theme
+	CellSize      int  // The width and height (in pixel) of each square
+	BorderWidth   int  // The width (in pixel) of the border around the maze
+	GridLines     bool // Draw a 1px line between squares
+	Legend        bool // Draw the color keys and the solving stats under the maze
+	FrameCounters bool // Draw the step, frontier size and explored count on each GIF frame
+	SolutionOnly  bool // Only draw the maze and the solution path in images, without the explored squares
+	// Print the expansion index inside each explored square of the image. Skipped if the numbers don't fit the squares
+	ExpansionOrder bool
+	FrameStride    int // Only every Nth step of the solver becomes a GIF frame
+	// The maximum number of sampled GIF frames, 0 means no limit. The frame stride is increased to stay under it, and
+	// the last step and solution frame are always kept
+	MaxFrames int
+	// Color the solution path with a gradient from Theme.Path to Theme.PathEnd keyed to the accumulated cost, so the
+	// expensive segments stand out. Only used on weighted mazes
+	PathGradient bool
+	// Render the solution image as a true color PNG with a transparent background, so it can be overlaid on other
+	// artwork. The GIF is not affected
+	Transparent bool
+	// The maximum number of pixels of the maze part of an image, 0 means no limit. The cell size is reduced
+	// automatically to stay under it
+	MaxImagePixels int
+	// The memory budget of a GIF in bytes, estimated as frames x frame size, 0 means no limit. The frames are sampled
+	// to stay under it, and the GIF is refused if even the first and last frames don't fit
+	MaxGIFBytes int64
+	FrameDelay  time.Duration // How long each step of the GIF is shown, before Speed is applied
+	FinalHold   time.Duration // How long the final frame with the solution is shown, before Speed is applied
+	Speed       float64       // Playback speedup of the GIF: 2 plays twice as fast. The delays are rounded to 10ms, at least 10ms
+	// Called after every GIF frame is written, with the number of frames written so far and the number of frames of
+	// the GIF, so the progress of a long encoding can be shown
+	OnFrame func(done, total int)
+}
+
+// Number of colors in the path gradient, they are appended after the theme colors in the palette
+const pathGradientSteps = 16
+
+// Default render options
+func DefaultRenderOptions() RenderOptions {
+	return RenderOptions{
+		Theme:          LightTheme,
+		CellSize:       20,
+		BorderWidth:    2,
+		FrameStride:    1,
+		MaxFrames:      1000,
+		PathGradient:   true,
+		MaxImagePixels: 50_000_000,
+		MaxGIFBytes:    1 << 30,
+		FrameDelay:     200 * time.Millisecond,
+		FinalHold:      3 * time.Second,
+		Speed:          1,
+	}
+}
+
+// Build the palette for rendering: the theme colors followed by the path gradient
+func (opts RenderOptions) palette() color.Palette {
+	palette := opts.Theme.Palette()
+	for i := range pathGradientSteps {
+		palette = append(palette, lerpColor(opts.Theme.Path, opts.Theme.PathEnd, float64(i)/float64(pathGradientSteps-1)))
+	}
+
+	return palette
+}
+
+// Get the palette index of each square on the solution path. Without gradient every square uses the path color,
+// otherwise the color is picked by the fraction of the total path cost accumulated when reaching that square
+func (opts RenderOptions) pathColorIndexes(m *maze.Solved) []uint8 {
+	indexes := make([]uint8, len(m.Solution.Path))
+	total := m.PathCost
+	if !opts.PathGradient || total <= len(m.Solution.Path) {
+		// Unweighted path, the cost is the same as the length
+		for i := range indexes {
+			indexes[i] = 6
+		}
+		return indexes
+	}
+
+	// The gradient starts right after the theme colors
+	offset := len(opts.Theme.Palette())
+	accumulated := 0
+	for i, p := range m.Solution.Path {
+		accumulated += m.Cost(p)
+		step := accumulated * (pathGradientSteps - 1) / total
+		indexes[i] = uint8(offset + step)
+	}
+
+	return indexes
+}
+
+// Linear interpolation between two colors, t in [0, 1]
+func lerpColor(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), mix(a.A, b.A)}
+}
+
+// Check if the render options are usable
+func (opts RenderOptions) Validate() error {
+	if opts.CellSize <= 0 {
+		return fmt.Errorf("cell size must be positive, got %d", opts.CellSize)
+	}
+
+	if opts.BorderWidth < 0 {
+		return fmt.Errorf("border width must not be negative, got %d", opts.BorderWidth)
+	}
+
+	if opts.FrameStride <= 0 {
+		return fmt.Errorf("frame stride must be positive, got %d", opts.FrameStride)
+	}
+
+	if opts.MaxFrames < 0 {
+		return fmt.Errorf("max frames must not be negative, got %d", opts.MaxFrames)
+	}
+
+	if opts.MaxImagePixels < 0 {
+		return fmt.Errorf("max image pixels must not be negative, got %d", opts.MaxImagePixels)
+	}
+
+	if opts.MaxGIFBytes < 0 {
+		return fmt.Errorf("max GIF bytes must not be negative, got %d", opts.MaxGIFBytes)
+	}
+
+	if opts.FrameDelay <= 0 || opts.FinalHold <= 0 {
+		return fmt.Errorf("frame delay and final hold must be positive, got %s and %s", opts.FrameDelay, opts.FinalHold)
+	}
+
+	if opts.Speed <= 0 {
+		return fmt.Errorf("speed must be positive, got %g", opts.Speed)
+	}
+
+	return nil
+}
+
+// Get the actual frame stride for an animation of 'steps' steps. If the configured stride would still produce more
+// than MaxFrames frames, the stride is increased until it fits
+func (opts RenderOptions) frameStride(steps int) int {
+	stride := max(opts.FrameStride, 1)
+	if opts.MaxFrames > 0 && steps > opts.MaxFrames*stride {
+		stride = (steps + opts.MaxFrames - 1) / opts.MaxFrames
+	}
+
+	return stride
+}
+
+// Reduce the cell size so the maze image stays under the pixel budget. The cell size never goes below 1 pixel, so a
+// maze with more squares than the budget is still rendered, only warned about
+func (opts RenderOptions) fit(m *maze.Solved) RenderOptions {
+	if opts.MaxImagePixels == 0 {
+		return opts
+	}
+
+	pixels := func(cellSize int) int {
+		return (m.Width*cellSize + 2*opts.BorderWidth) * (m.Height*cellSize + 2*opts.BorderWidth)
+	}
+
+	cellSize := opts.CellSize
+	for cellSize > 1 && pixels(cellSize) > opts.MaxImagePixels {
+		cellSize--
+	}
+
+	if cellSize != opts.CellSize {
+		slog.Warn("Cell size reduced to fit the image pixel budget", "from", opts.CellSize, "to", cellSize,
+			"max", opts.MaxImagePixels)
+		opts.CellSize = cellSize
+	}
+	if pixels(cellSize) > opts.MaxImagePixels {
+		slog.Warn("Maze image exceeds the pixel budget even at 1 pixel per square", "pixels", pixels(cellSize),
+			"max", opts.MaxImagePixels)
+	}
+
+	return opts
+}
+
+// Lower MaxFrames so the frames of a GIF of 'steps' steps, each of width x height paletted pixels, stay under the memory
+// budget. Return an error if the budget can't even hold the first frame and the solution frame
+func (opts RenderOptions) fitGIFMemory(steps, width, height int) (RenderOptions, error) {
+	if opts.MaxGIFBytes == 0 {
+		return opts, nil
+	}
+
+	// One byte per pixel, the solution frame is always kept
+	frameBytes := int64(width) * int64(height)
+	frames := opts.MaxGIFBytes/frameBytes - 1
+	if frames < 1 {
+		return opts, fmt.Errorf("a %dx%d GIF frame takes %.1f MB, so the %.1f MB GIF memory budget can't hold the first "+
+			"and last frames: raise the budget or lower the cell size", width, height, megabytes(frameBytes),
+			megabytes(opts.MaxGIFBytes))
+	}
+
+	if opts.MaxFrames == 0 || frames < int64(opts.MaxFrames) {
+		opts.MaxFrames = int(min(frames, int64(steps)+1))
+	}
+
+	return opts, nil
+}
+
+// Get a number of bytes in MB, rounded to 0.1
+func megabytes(n int64) float64 {
+	return math.Round(float64(n)/(1<<20)*10) / 10
+}
+
+// Get the rectangle of the square at (row, col) in the rendered image
+func (opts RenderOptions) cellRect(row, col int) image.Rectangle {
+	return image.Rect(
+		col*opts.CellSize+opts.BorderWidth,
+		row*opts.CellSize+opts.BorderWidth,
+		(col+1)*opts.CellSize+opts.BorderWidth,
+		(row+1)*opts.CellSize+opts.BorderWidth,
+	)
+}
+
+// Get the rectangles of the start and the goal. A start that is also the goal is split in two, the start on the left
+// half of the square and the goal on the right half, so both can be seen
+func (opts RenderOptions) endRects(m *maze.Maze) (start, goal image.Rectangle) {
+	start, goal = opts.cellRect(m.Start.Row, m.Start.Col), opts.cellRect(m.Goal.Row, m.Goal.Col)
+	if m.Start == m.Goal {
+		start.Max.X -= start.Dx() / 2
+		goal.Min.X = start.Max.X
+	}
+
+	return start, goal
+}
+
+// Get the first and last row (or column) of squares that the pixels from 'from' to 'to' (excluded) fall on
+func (opts RenderOptions) cellSpan(from, to int) (int, int) {
+	return (from - opts.BorderWidth) / opts.CellSize, (to - 1 - opts.BorderWidth) / opts.CellSize
+}
+
+// Draw the grid lines between squares. Lines are drawn on the top and left edge of each square, so the grid
+// doesn't change the size of the image
+func drawGridLines(img draw.Image, m *maze.Solved, opts RenderOptions, c color.Color) {
+	if !opts.GridLines || opts.CellSize < 3 {
+		return
+	}
+
+	// Only the lines inside the image are drawn, a delta frame of a GIF only covers a few squares
+	bounds := img.Bounds()
+	firstRow, lastRow := opts.cellSpan(bounds.Min.Y, bounds.Max.Y)
+	firstCol, lastCol := opts.cellSpan(bounds.Min.X, bounds.Max.X)
+
+	uniform := &image.Uniform{c}
+	for row := max(firstRow, 1); row <= min(lastRow+1, m.Height-1); row++ {
+		y := row*opts.CellSize + opts.BorderWidth
+		line := image.Rect(opts.BorderWidth, y, m.Width*opts.CellSize+opts.BorderWidth, y+1)
+		draw.Draw(img, line, uniform, image.Point{}, draw.Src)
+	}
+
+	for col := max(firstCol, 1); col <= min(lastCol+1, m.Width-1); col++ {
+		x := col*opts.CellSize + opts.BorderWidth
+		line := image.Rect(x, opts.BorderWidth, x+1, m.Height*opts.CellSize+opts.BorderWidth)
+		draw.Draw(img, line, uniform, image.Point{}, draw.Src)
+	}
+}
+
+// Create GIF animation for maze solving.
+// Only the first frame contains the whole maze. Every following frame only covers the rectangle that changed since the
+// previous frame (the old cursor, the newly visited and enqueued squares and the new cursor), and the unchanged pixels inside that
+// rectangle are transparent, so the viewer keeps showing the previous frame underneath.
+func CreateGIF(m *maze.Solved, opts RenderOptions) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	if err := WriteGIF(buf, m, opts); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// Encode the GIF animation of maze solving into w. Each frame is written out as soon as it is drawn, so only one frame
+// is kept in memory at a time
+func WriteGIF(w io.Writer, m *maze.Solved, opts RenderOptions) error {
+	opts = opts.fit(m)
+
+	// The extra transparent color is used for unchanged pixels in delta frames
+	palette := append(opts.palette(), color.Transparent)
+	transparent := uint8(len(palette) - 1)
+
+	width, height, counterRect := opts.gifLayout(m)
+	budgeted, err := opts.fitGIFMemory(len(m.ExperimentPath), width, height)
+	if err != nil {
+		return err
+	}
+	if from, to := max(opts.FrameStride, 1), opts.frameStride(len(m.ExperimentPath)); from != to {
+		slog.Warn("Frame stride increased to stay under the GIF frame limit", "from", from, "to", to,
+			"steps", len(m.ExperimentPath), "max_frames", opts.MaxFrames)
+	}
+	if from, to := opts.frameStride(len(m.ExperimentPath)), budgeted.frameStride(len(m.ExperimentPath)); from != to {
+		slog.Warn("Frame stride increased to fit the GIF memory budget", "from", from, "to", to,
+			"frame_mb", megabytes(int64(width)*int64(height)), "budget_mb", megabytes(opts.MaxGIFBytes))
+	}
+	opts = budgeted
+
+	// Create GIF
+	g, err := newGIFWriter(w, width, height, palette, int(transparent))
+	if err != nil {
+		return err
+	}
+
+	// Use a map to track visited points progressively
+	visited := make(map[maze.Point]bool)
+
+	// Squares that changed since the last frame
+	var changed []maze.Point
+
+	// Number of squares of m.Enqueued already shown in the frontier
+	enqueued := 0
+
+	// Visited squares are drawn as such, the others that changed are waiting in the frontier
+	cellColor := func(p maze.Point) color.Color {
+		if visited[p] {
+			return palette[4]
+		}
+		return palette[11]
+	}
+
+	// Only every Nth step become a frame
+	stride := opts.frameStride(len(m.ExperimentPath))
+	frames, total := 0, gifFrames(len(m.ExperimentPath), stride, m.Found() || m.Unreachable)
+	wrote := func() {
+		frames++
+		if opts.OnFrame != nil {
+			opts.OnFrame(frames, total)
+		}
+	}
+
+	// Loop through every square the solver/cursor has moved
+	var cursor *maze.Point
+	for i := 0; i < len(m.ExperimentPath); i++ {
+		current := m.ExperimentPath[i]
+
+		// Mark as visited if not already (first appearance)
+		if !visited[current] {
+			visited[current] = true
+			changed = append(changed, current)
+		}
+
+		// The squares added to the frontier before this step
+		if i < len(m.Counters) {
+			for ; enqueued < min(m.Counters[i].Generated, len(m.Enqueued)); enqueued++ {
+				if p := m.Enqueued[enqueued]; !visited[p] {
+					changed = append(changed, p)
+				}
+			}
+		}
+
+		// Skip the steps that don't fall on the stride, but always keep the last step
+		if i%stride != 0 && i != len(m.ExperimentPath)-1 {
+			continue
+		}
+
+		var img *image.Paletted
+		if cursor == nil {
+			// The first frame is the whole maze
+			img = image.NewPaletted(image.Rect(0, 0, width, height), palette)
+			drawBase(img, m, opts, palette)
+			drawLegend(img, m, opts, palette, false)
+			for _, p := range changed {
+				draw.Draw(img, opts.cellRect(p.Row, p.Col), &image.Uniform{cellColor(p)}, image.Point{}, draw.Over)
+			}
+		} else {
+			// The old cursor is now just a visited square
+			changed = append(changed, *cursor)
+			bounds := cellsBounds(opts, append(changed, current))
+			if opts.FrameCounters {
+				bounds = bounds.Union(counterRect)
+			}
+
+			img = newDeltaFrame(bounds, palette, transparent)
+			for _, p := range changed {
+				draw.Draw(img, opts.cellRect(p.Row, p.Col), &image.Uniform{cellColor(p)}, image.Point{}, draw.Src)
+			}
+		}
+
+		// Draw cursor (solver position)
+		draw.Draw(img, opts.cellRect(current.Row, current.Col), &image.Uniform{palette[5]}, image.Point{}, draw.Over)
+
+		// Start and goal are always on top. The rectangles outside of the frame are clipped by draw
+		drawEnds(img, m, opts, palette)
+		drawGridLines(img, m, opts, palette[10])
+
+		if opts.FrameCounters && i < len(m.Counters) {
+			drawCounters(img, counterRect, palette, i, m.Counters[i])
+		}
+
+		if err := g.WriteFrame(img, opts.gifDelay(opts.FrameDelay), gif.DisposalNone); err != nil {
+			return err
+		}
+		wrote()
+
+		cursor = &current
+		changed = changed[:0]
+	}
+
+	// If solution found, add a final frame with solution path highlighted (no cursor). A maze without one gets a final
+	// frame with its goal crossed out
+	if m.Found() || m.Unreachable {
+		// Only the solution path (or the goal) and the last cursor changed
+		cells := slices.Clone(m.Solution.Path)
+		if m.Unreachable {
+			cells = append(cells, m.Goal)
+		}
+		if cursor != nil {
+			cells = append(cells, *cursor)
+		}
+
+		// The stats banner is only shown on the final frame
+		bounds := cellsBounds(opts, cells)
+		if opts.Legend {
+			bounds = bounds.Union(opts.statsRect(m))
+		}
+
+		img := newDeltaFrame(bounds, palette, transparent)
+		if cursor != nil {
+			draw.Draw(img, opts.cellRect(cursor.Row, cursor.Col), &image.Uniform{palette[4]}, image.Point{}, draw.Src)
+		}
+
+		// Draw solution path (magenta)
+		pathColors := opts.pathColorIndexes(m)
+		for i, p := range m.Solution.Path {
+			draw.Draw(img, opts.cellRect(p.Row, p.Col), &image.Uniform{palette[pathColors[i]]}, image.Point{}, draw.Src)
+		}
+
+		// Draw start and goal on top
+		drawEnds(img, m, opts, palette)
+		drawUnreachable(img, m, opts, palette)
+		drawGridLines(img, m, opts, palette[10])
+		drawLegend(img, m, opts, palette, true)
+
+		if err := g.WriteFrame(img, opts.gifDelay(opts.FinalHold), gif.DisposalNone); err != nil {
+			return err
+		}
+		wrote()
+	}
+
+	return g.Close()
+}
+
+// Get a delay sped up by Speed in 100ths of a second, the unit of GIF delays. It's at least 1
+func (opts RenderOptions) gifDelay(d time.Duration) int {
+	return max(1, int(math.Round(d.Seconds()/opts.Speed*100)))
+}
+
+// Get the number of frames of the GIF animation of steps steps: every stride-th step, the last step and the final
+// frame if there is one, which shows the solution or the unreachable goal
+func gifFrames(steps, stride int, solved bool) int {
+	frames := (steps + stride - 1) / stride
+	if steps > 0 && (steps-1)%stride != 0 {
+		frames++
+	}
+	if solved {
+		frames++
+	}
+
+	return frames
+}
+
+// Get the size of the GIF animation, and where its counter strip is (empty if there is none)
+func (opts RenderOptions) gifLayout(m *maze.Solved) (int, int, image.Rectangle) {
+	width, height := opts.canvasSize(m)
+
+	// The counter strip is at the bottom of the animation
+	var counterRect image.Rectangle
+	if opts.FrameCounters {
+		width = max(width, counterMinWidth)
+		counterRect = image.Rect(0, height, width, height+legendLineHeight+2*legendPadding)
+		height = counterRect.Max.Y
+	}
+
+	return width, height, counterRect
+}
+
+// Check that the GIF animation of the maze fits the GIF memory budget, so it can be refused before anything is written
+func CheckGIFBudget(m *maze.Solved, opts RenderOptions) error {
+	opts = opts.fit(m)
+	width, height, _ := opts.gifLayout(m)
+	_, err := opts.fitGIFMemory(len(m.ExperimentPath), width, height)
+	return err
+}
+
+// Get the bounding rectangle of the given squares
+func cellsBounds(opts RenderOptions, cells []maze.Point) image.Rectangle {
+	bounds := image.Rectangle{}
+	for _, p := range cells {
+		bounds = bounds.Union(opts.cellRect(p.Row, p.Col))
+	}
+
+	return bounds
+}
+
+// Create a frame which covers only the given bounds, filled with the transparent color
+func newDeltaFrame(bounds image.Rectangle, palette color.Palette, transparent uint8) *image.Paletted {
+	img := image.NewPaletted(bounds, palette)
+	for i := range img.Pix {
+		img.Pix[i] = transparent
+	}
+
+	return img
+}
+
+// Draw the static part of the maze: background, border, walls and weighted squares
+func drawBase(img draw.Image, m *maze.Solved, opts RenderOptions, palette color.Palette) {
+	// Draw background (white)
+	draw.Draw(img, img.Bounds(), &image.Uniform{palette[0]}, image.Point{}, draw.Src)
+
+	// Draw border (blue)
+	mazeWidth, mazeHeight := opts.mazeSize(m)
+	borderRect := image.Rect(opts.BorderWidth, opts.BorderWidth, mazeWidth-opts.BorderWidth, mazeHeight-opts.BorderWidth)
+	draw.Draw(img, borderRect, &image.Uniform{palette[7]}, image.Point{}, draw.Over)
+
+	// Draw base maze (empty white, walls black, weighted orange)
+	for row := 0; row < m.Height; row++ {
+		for col := 0; col < m.Width; col++ {
+			sq := m.Square(maze.Point{Row: row, Col: col})
+
+			// Check if this is a wall or empty square
+			colIdx := 0 // empty
+			if sq.IsWall {
+				colIdx = 1 // wall
+			} else if sq.Cost > 1 {
+				colIdx = 8 // weighted square (orange)
+			}
+
+			// Draw square
+			draw.Draw(img, opts.cellRect(row, col), &image.Uniform{palette[colIdx]}, image.Point{}, draw.Src)
+
+			// Draw cost text for weighted squares (Cost > 1)
+			if sq.Cost > 1 && !sq.IsWall {
+				drawCost(img, opts, row, col, sq.Cost, palette[9])
+			}
+		}
+	}
+}
+
+// Write the cost of a weighted square in its cell
+func drawCost(img draw.Image, opts RenderOptions, row, col, cost int, c color.Color) {
+	// Center the text in the cell
+	x := col*opts.CellSize + opts.BorderWidth + opts.CellSize/4
+	y := row*opts.CellSize + opts.BorderWidth + opts.CellSize/2
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)},
+	}
+	drawer.DrawString(fmt.Sprintf("%d", cost))
+}
+
+// Draw the start (green) and the goal (red) on top of the squares under them
+func drawEnds(img draw.Image, m *maze.Solved, opts RenderOptions, palette color.Palette) {
+	start, goal := opts.endRects(m.Maze)
+	draw.Draw(img, start, &image.Uniform{palette[2]}, image.Point{}, draw.Over)
+	draw.Draw(img, goal, &image.Uniform{palette[3]}, image.Point{}, draw.Over)
+}
+
+// Cross out the goal with the wall color when the solver has explored everything it could reach without finding it
+func drawUnreachable(img draw.Image, m *maze.Solved, opts RenderOptions, palette color.Palette) {
+	if !m.Unreachable {
+		return
+	}
+
+	rect := opts.cellRect(m.Goal.Row, m.Goal.Col)
+	thickness := max(opts.CellSize/8, 1)
+	for i := range rect.Dx() {
+		for t := range thickness {
+			y := min(i+t, rect.Dy()-1)
+			img.Set(rect.Min.X+i, rect.Min.Y+y, palette[1])
+			img.Set(rect.Max.X-1-i, rect.Min.Y+y, palette[1])
+		}
+	}
+}
+
+// Create the PNG image of the solved maze
+func CreateSolutionImage(m *maze.Solved, opts RenderOptions) (*bytes.Buffer, error) {
+	img := renderSolution(m, opts)
+
+	// Encode as PNG
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %v", err)
+	}
+
+	return buf, nil
+}
+
+// Draw the solved maze: the maze itself, explored squares and the solution path
+func renderSolution(m *maze.Solved, opts RenderOptions) draw.Image {
+	opts = opts.fit(m)
+
+	palette := opts.palette()
+
+	// Define the width and height of the maze image
+	width, height := opts.canvasSize(m)
+
+	// Create image. A paletted image can't be partly transparent when encoded, so the transparent mode draws into
+	// a true color image with the background (and empty squares) cleared
+	var img draw.Image
+	if opts.Transparent {
+		palette[0] = color.Transparent
+		img = image.NewNRGBA(image.Rect(0, 0, width, height))
+	} else {
+		img = image.NewPaletted(image.Rect(0, 0, width, height), palette)
+	}
+
+	drawBase(img, m, opts, palette)
+
+	// Draw visited squares (gray)
+	if !opts.SolutionOnly {
+		for _, p := range m.Explored {
+			rect := opts.cellRect(p.Row, p.Col)
+			draw.Draw(img, rect, &image.Uniform{palette[4]}, image.Point{}, draw.Over)
+		}
+	}
+
+	// Draw solution path (magenta)
+	pathColors := opts.pathColorIndexes(m)
+	for i, p := range m.Solution.Path {
+		rect := opts.cellRect(p.Row, p.Col)
+		draw.Draw(img, rect, &image.Uniform{palette[pathColors[i]]}, image.Point{}, draw.Over)
+	}
+
+	// Weighted squares stay visible on top of the visited ones. The path keeps its gradient, only the cost is written
+	// again on it
+	onPath := make(map[maze.Point]bool, len(m.Solution.Path))
+	for _, p := range m.Solution.Path {
+		onPath[p] = true
+	}
+	for row := 0; row < m.Height; row++ {
+		for col := 0; col < m.Width; col++ {
+			sq := m.Square(maze.Point{Row: row, Col: col})
+			if sq.IsWall || sq.Cost <= 1 {
+				continue
+			}
+
+			if !onPath[maze.Point{Row: row, Col: col}] {
+				draw.Draw(img, opts.cellRect(row, col), &image.Uniform{palette[8]}, image.Point{}, draw.Src)
+			}
+			drawCost(img, opts, row, col, sq.Cost, palette[9])
+		}
+	}
+
+	// Draw start (green) and goal (red)
+	drawEnds(img, m, opts, palette)
+	drawUnreachable(img, m, opts, palette)
+
+	drawGridLines(img, m, opts, palette[10])
+	drawExpansionOrder(img, m, opts, palette)
+	drawLegend(img, m, opts, palette, true)
+
+	return img
+}
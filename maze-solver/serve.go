@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+	"github.com/danglnh07/go-ai/maze-solver/storage"
+)
+
+// The web page of the server, index.html is answered for /
+//
+//go:embed webui/index.html
+var webUIFiles embed.FS
+
+// The files of webUIFiles, rooted at the webui directory
+var webUI, _ = fs.Sub(webUIFiles, "webui")
+
+// Options of the serve command
+type ServeConfig struct {
+	Addr     string        // Address the server listens on
+	GRPCAddr string        // Address the gRPC server listens on, no gRPC server when empty
+	Timeout  time.Duration // Stop each solve after this long
+	MaxBytes int64         // Size of the largest maze accepted
+	MaxCells int           // Most squares (height x width) of a maze accepted, 0 means no limit
+
+	JobWorkers int           // Jobs solved at the same time
+	JobQueue   int           // Jobs waiting for a worker at most
+	JobTimeout time.Duration // Stop each job after this long
+	Storage    string        // Where the artifacts of the jobs are written, see storage.Open, none when empty
+	StorageURL string        // The URL the artifacts are downloaded from, see storage.Open
+
+	Keys       string  // The JSON file of the API keys and their limits, see loadAPIKeys
+	RequireKey bool    // Refuse the solves without a valid API key
+	Rate       float64 // Solve requests per second allowed per client (API key or IP address), 0 means no limit
+	Burst      int     // Solve requests allowed at once per client
+
+	Origins string // Comma separated origins whose web pages may open /solve/stream besides the server's, "*" for any
+
+	metrics   *serverMetrics    // Shared by the HTTP and gRPC servers, created by NewServer if nil
+	jobs      *jobQueue         // The jobs of /jobs, created by NewServer if nil
+	artifacts storage.Storage   // Opened from Storage by Serve
+	keys      map[string]apiKey // Loaded from Keys by Serve
+	limiter   *rateLimiter      // The buckets of the clients, shared by the HTTP and gRPC servers, created by them if nil
+}
+
+// Run the serve command: answer the HTTP API until Ctrl+C
+func Serve(args []string) error {
+	cfg := ServeConfig{}
+	fs := newFlagSet("serve", "", "Solve mazes over HTTP. POST /solve takes the maze text as the body and the algo, heuristic and\n"+
+		"movement query parameters (like the flags of solve), and answers the JSON export of the solve, or its PNG or GIF\n"+
+		"with format=png or gif. GET /solve/stream\n"+
+		"takes the same parameters and opens a WebSocket: send the maze text as the first message, and every expansion and\n"+
+		"frontier change is streamed back as a JSON message while solving, then the path. With -grpc-addr, the\n"+
+		"MazeSolver service of mazepb/maze_solver.proto is served too. GET /metrics answers the metrics of the solves\n"+
+		"in the text format of Prometheus. GET / is a web page to paste or draw a maze and watch it being solved.\n"+
+		"GET /openapi.json answers the OpenAPI document of the HTTP API. For large mazes, POST /jobs takes the same\n"+
+		"request as POST /solve, queues the solve and answers its id right away, and GET /jobs/{id} reports its state,\n"+
+		"its progress and, once done, the JSON export. With -storage, artifacts=png,gif,json writes those artifacts of the\n"+
+		"job into the storage, and the job reports their URLs.\n\n"+
+		"POST /solve, GET /solve/stream, POST /jobs and the gRPC calls are limited per client: an API key of -keys, sent as\n"+
+		"Authorization: Bearer <key>, X-API-Key (headers, or metadata over gRPC) or the api_key query parameter, or the IP\n"+
+		"address without a key.\n"+
+		"The -keys file is a JSON array of {\"key\", \"name\", \"rate\", \"burst\", \"max_cells\"}, the limits left\n"+
+		"out are the ones of the flags. With -require-key, the requests without a key are refused.")
+	fs.StringVar(&cfg.Addr, "addr", ":8080", "The address to listen on")
+	fs.StringVar(&cfg.GRPCAddr, "grpc-addr", "", "The address the gRPC server listens on, none by default")
+	fs.DurationVar(&cfg.Timeout, "timeout", 30*time.Second, "Stop each solve after this long")
+	fs.Int64Var(&cfg.MaxBytes, "max-bytes", 16<<20, "The size of the largest maze accepted, in bytes")
+	fs.IntVar(&cfg.MaxCells, "max-cells", 4_000_000, "The most squares (height x width) of a maze accepted, 0 means no limit")
+	fs.IntVar(&cfg.JobWorkers, "job-workers", 2, "How many jobs of /jobs are solved at the same time")
+	fs.IntVar(&cfg.JobQueue, "job-queue", 100, "How many jobs of /jobs can wait for a worker, more are refused")
+	fs.DurationVar(&cfg.JobTimeout, "job-timeout", 10*time.Minute, "Stop each job of /jobs after this long")
+	fs.StringVar(&cfg.Storage, "storage", "", "Where the artifacts of the jobs are written: a directory (served under /artifacts/)\n"+
+		"or s3://bucket/prefix, configured by AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_REGION and S3_ENDPOINT")
+	fs.StringVar(&cfg.StorageURL, "storage-url", "", "The URL the names of the artifacts are appended to, e.g. a CDN in front of -storage")
+	fs.StringVar(&cfg.Keys, "keys", "", "The JSON file of the API keys and their limits")
+	fs.BoolVar(&cfg.RequireKey, "require-key", false, "Refuse the solves without a valid API key")
+	fs.Float64Var(&cfg.Rate, "rate", 0, "Solve requests per second allowed per client (API key or IP address), 0 means no limit")
+	fs.IntVar(&cfg.Burst, "burst", 10, "Solve requests a client can send at once before -rate applies")
+	fs.StringVar(&cfg.Origins, "origins", "", "The comma separated origins (e.g. https://example.com) whose web pages may open\n"+
+		"the WebSocket of /solve/stream besides the pages of the server, \"*\" for any")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	if cfg.Keys != "" {
+		keys, err := loadAPIKeys(cfg.Keys)
+		if err != nil {
+			return err
+		}
+		cfg.keys = keys
+	} else if cfg.RequireKey {
+		return fmt.Errorf("-require-key needs the API keys of -keys")
+	}
+	if cfg.Storage != "" {
+		artifacts, err := storage.Open(cfg.Storage, cfg.StorageURL)
+		if err != nil {
+			return err
+		}
+		cfg.artifacts = artifacts
+	}
+	cfg.metrics = newServerMetrics()
+	cfg.limiter = newRateLimiter()
+	cfg.jobs = newJobQueue(ctx, cfg)
+	srv := &http.Server{Addr: cfg.Addr, Handler: NewServer(cfg)}
+
+	if cfg.GRPCAddr != "" {
+		lis, err := net.Listen("tcp", cfg.GRPCAddr)
+		if err != nil {
+			return err
+		}
+
+		grpcSrv := NewGRPCServer(cfg)
+		go func() {
+			<-ctx.Done()
+			grpcSrv.GracefulStop()
+		}()
+		go func() {
+			LOGGER.Info("Serving gRPC", "addr", cfg.GRPCAddr)
+			if err := grpcSrv.Serve(lis); err != nil {
+				LOGGER.Error("gRPC server stopped", "error", err)
+				stop()
+			}
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+
+	LOGGER.Info("Serving", "addr", cfg.Addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Create the handler of the HTTP API
+func NewServer(cfg ServeConfig) http.Handler {
+	if cfg.metrics == nil {
+		cfg.metrics = newServerMetrics()
+	}
+	if cfg.jobs == nil {
+		cfg.jobs = newJobQueue(context.Background(), cfg)
+	}
+
+	if cfg.limiter == nil {
+		cfg.limiter = newRateLimiter()
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /solve", cfg.guard("http", cfg.handleSolve))
+	mux.HandleFunc("GET /solve/stream", cfg.guard("websocket", cfg.handleStream))
+	mux.HandleFunc("GET /metrics", cfg.metrics.handleMetrics)
+	mux.HandleFunc("GET /openapi.json", cfg.handleOpenAPI)
+	mux.HandleFunc("POST /jobs", cfg.guard("jobs", cfg.handleSubmitJob))
+	mux.HandleFunc("GET /jobs/{id}", cfg.handleJob)
+	if local, ok := cfg.artifacts.(*storage.Local); ok && cfg.StorageURL == "" {
+		mux.Handle("GET /artifacts/", http.StripPrefix("/artifacts/", http.FileServer(http.Dir(local.Dir))))
+	}
+	mux.Handle("GET /", http.FileServerFS(webUI))
+	return mux
+}
+
+// Get the algorithm, topology and heuristic named in a solve request, A* with four-way moves when the names are empty
+func solveParams(algoName, movement, heuristic string) (maze.Algo, maze.Topology, solve.Heuristic, error) {
+	algo := maze.Algo(cmp.Or(algoName, string(maze.ASTAR)))
+	if !slices.Contains(serverAlgos, algo) {
+		names := make([]string, len(serverAlgos))
+		for i, a := range serverAlgos {
+			names[i] = string(a)
+		}
+		return "", nil, nil, fmt.Errorf("unsupported algorithm %q, supported: %s", algo, strings.Join(names, ", "))
+	}
+
+	topology, err := maze.TopologyByName(cmp.Or(movement, "four"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var h solve.Heuristic
+	if heuristic != "" {
+		if h, err = solve.HeuristicByName(heuristic); err != nil {
+			return "", nil, nil, err
+		}
+	}
+
+	return algo, topology, h, nil
+}
+
+// Load the maze text of a request with topology. Its size is checked against the limit of the client of ctx, MaxCells
+// without one, before anything is allocated for its squares
+func (cfg ServeConfig) loadMaze(ctx context.Context, text string, topology maze.Topology) (*maze.Maze, error) {
+	m := &maze.Maze{MaxCells: cfg.maxCells(ctx)}
+	if err := m.Load(text); err != nil {
+		return nil, err
+	}
+	m.Topology = topology
+
+	return m, nil
+}
+
+// Solve the maze of the request body, and answer the JSON export of the solve, or its PNG or GIF with the format query
+// parameter. A maze without a solution is still answered, with an empty solution
+func (cfg ServeConfig) handleSolve(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	algo, topology, h, err := solveParams(query.Get("algo"), query.Get("movement"), query.Get("heuristic"))
+	if err != nil {
+		cfg.metrics.failed("http", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := cmp.Or(query.Get("format"), "json")
+	if !slices.Contains(solveFormats, format) {
+		cfg.metrics.failed("http", reasonBadRequest)
+		http.Error(w, fmt.Sprintf("unknown format %q, supported: json, png, gif", format), http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, cfg.MaxBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		cfg.metrics.failed("http", reasonTooLarge)
+		http.Error(w, fmt.Sprintf("maze is larger than %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		cfg.metrics.failed("http", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m, err := cfg.loadMaze(r.Context(), string(body), topology)
+	var tooMany maze.ErrTooLarge
+	if errors.As(err, &tooMany) {
+		cfg.metrics.failed("http", reasonTooLarge)
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		cfg.metrics.failed("http", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Only the GIF animates the trace
+	var opts []solve.Option
+	if format != "gif" {
+		opts = append(opts, solve.WithoutTrace())
+	}
+	solver, err := solve.NewSolverForAlgo(algo, m, h, opts...)
+	if err != nil {
+		cfg.metrics.failed("http", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), cfg.Timeout)
+	defer cancel()
+	result, err := solver.SolveContext(ctx)
+	cfg.metrics.solved("http", algo, result, err)
+
+	var invalid maze.ErrInvalidMaze
+	var cost maze.ErrUnsupportedCost
+	switch {
+	case errors.As(err, &invalid), errors.As(err, &cost):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		http.Error(w, fmt.Sprintf("solving took longer than %s", cfg.Timeout), http.StatusGatewayTimeout)
+		return
+	case err != nil && !errors.Is(err, solve.ErrNoSolution):
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	solved := &maze.Solved{Maze: m, SearchType: algo, Result: result}
+	if h := solve.HeuristicOf(solver); h != nil {
+		solved.Heuristic = h.Name()
+	}
+	LOGGER.Info("Maze solved over HTTP", "algo", algo, "second(s)", result.SolveTime.Seconds(),
+		"expanded", result.Expanded, "path_cost", result.PathCost)
+
+	if format == "gif" {
+		if err := render.CheckGIFBudget(solved, render.DefaultRenderOptions()); err != nil {
+			cfg.metrics.failed("http", reasonTooLarge)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	buf, err := cfg.renderFormat("request", solved, format)
+	if err != nil {
+		cfg.metrics.failed("http", reasonInternal)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", formatTypes[format])
+	w.Write(buf.Bytes())
+}
+
+// The content types of the formats of solveFormats
+var formatTypes = map[string]string{"json": "application/json", "png": "image/png", "gif": "image/gif"}
+
+// Render a solved maze in one of solveFormats with the default render options, name being the maze name of the JSON
+// export. The GIF memory budget should have been checked first
+func (cfg ServeConfig) renderFormat(name string, solved *maze.Solved, format string) (*bytes.Buffer, error) {
+	start := time.Now()
+	defer func() { cfg.metrics.rendered(format, time.Since(start)) }()
+
+	switch format {
+	case "json":
+		return render.CreateJSON(name, []*maze.Solved{solved})
+	case "png":
+		return render.CreateSolutionImage(solved, render.DefaultRenderOptions())
+	case "gif":
+		return render.CreateGIF(solved, render.DefaultRenderOptions())
+	}
+
+	return nil, fmt.Errorf("unknown format %q, supported: %s", format, strings.Join(solveFormats, ", "))
+}
@@ -0,0 +1,49 @@
+package solve
+
+import (
+	"context"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// A* implementation
+type AStarSolver struct {
+	gridSolver
+	Heuristic Heuristic // The estimate of the remaining cost to the goal
+}
+
+// A* Solver constructor, with the default heuristic of the topology of the maze, see DefaultHeuristic
+func NewAStarSolver(m *maze.Maze, opts ...Option) Solver {
+	return NewAStarSolverWithHeuristic(m, DefaultHeuristic(m.Topology), opts...)
+}
+
+// A* Solver constructor with a custom heuristic
+func NewAStarSolverWithHeuristic(m *maze.Maze, h Heuristic, opts ...Option) Solver {
+	return &AStarSolver{
+		gridSolver: newGridSolver(m, maze.ASTAR, opts),
+		Heuristic:  h,
+	}
+}
+
+// Solve maze using A*
+func (astar *AStarSolver) Solve() (maze.Result, error) {
+	return astar.SolveContext(context.Background())
+}
+
+// Solve maze using A*, stopping with the partial stats and the context error once ctx is done
+func (astar *AStarSolver) SolveContext(ctx context.Context) (maze.Result, error) {
+	// A*, is the combination of Dijkstra and GBFS works, its cost calculation basically the cost from the current node
+	// to the start node + the estimate cost from current node to the goal. Only the path cost is carried to the
+	// children, the estimate of a node is never added to the cost of its children. An expanded square is reopened when
+	// a cheaper path to it is found, which happens when the heuristic isn't consistent (e.g. euclidean with diagonals)
+	return astar.solve(ctx, &Search[maze.Point]{
+		Frontier: NewPriorityFrontier(costOrder()),
+		Estimate: func(p maze.Point) int {
+			return estimate(astar.Heuristic, p, astar.Maze.Goal)
+		},
+		Cost: func(node *Node[maze.Point]) int {
+			return node.PathCost + node.Estimate
+		},
+		Reopen: true,
+	})
+}
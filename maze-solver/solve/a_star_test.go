@@ -0,0 +1,94 @@
+package solve
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Both topologies, by their flag name
+var testTopologies = []struct {
+	name     string
+	topology maze.Topology
+}{
+	{"four", maze.FourWay{}},
+	{"eight", maze.EightWay{}},
+}
+
+// Load a maze of testdata moving with topology
+func loadTestMaze(t *testing.T, path string, topology maze.Topology) *maze.Maze {
+	t.Helper()
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &maze.Maze{Topology: topology}
+	if err := m.Load(string(text)); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+
+	return m
+}
+
+// Solve m, and check that the solution is a valid path of m whose cost is the one reported
+func solveValid(t *testing.T, solver Solver, m *maze.Maze) maze.Result {
+	t.Helper()
+
+	result, err := solver.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := maze.VerifyResult(m, result); err != nil {
+		t.Fatalf("invalid solution: %v", err)
+	}
+
+	return result
+}
+
+// A* finds a path as cheap as Dijkstra's on weighted mazes with every heuristic admissible with the topology, the
+// default one and the distance field included. detour.txt is a maze where A* used to carry the estimate of a node into
+// the cost of its children
+func TestAStarMatchesDijkstra(t *testing.T) {
+	paths, err := filepath.Glob("testdata/weighted/*.txt")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no weighted mazes in testdata: %v", err)
+	}
+
+	for _, path := range paths {
+		for _, tt := range testTopologies {
+			m := loadTestMaze(t, path, tt.topology)
+			dijkstra := solveValid(t, NewDijkstraSolver(m), m)
+
+			field, err := NewDistanceField(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			candidates := append(slices.Clone(heuristics), field, DefaultHeuristic(tt.topology))
+
+			name := strings.TrimSuffix(filepath.Base(path), ".txt")
+			for i, h := range candidates {
+				if !Admissible(h, tt.topology) {
+					continue
+				}
+
+				label := h.Name()
+				if i == len(candidates)-1 {
+					label = "default"
+				}
+				t.Run(fmt.Sprintf("%s/%s/%s", name, tt.name, label), func(t *testing.T) {
+					astar := solveValid(t, NewAStarSolverWithHeuristic(m, h), m)
+					if astar.PathCost != dijkstra.PathCost {
+						t.Errorf("A* path costs %d, Dijkstra's %d", astar.PathCost, dijkstra.PathCost)
+					}
+				})
+			}
+		}
+	}
+}
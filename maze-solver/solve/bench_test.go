@@ -0,0 +1,147 @@
+package solve
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The size of the benchmark mazes
+const benchSize = 1000
+
+var (
+	// An open benchSize x benchSize maze from corner to corner, where the frontier of Dijkstra and A* is the widest
+	benchOpen = sync.OnceValue(func() *maze.Maze {
+		m, err := maze.NewMazeBuilder(benchSize, benchSize).SetStart(maze.Point{}).
+			SetGoal(maze.Point{Row: benchSize - 1, Col: benchSize - 1}).Build()
+		if err != nil {
+			panic(err)
+		}
+		return m
+	})
+
+	// A generated benchSize+1 x benchSize+1 maze with a tenth of its inner walls knocked down
+	benchMaze = sync.OnceValue(func() *maze.Maze {
+		m, err := maze.Generate(benchSize+1, benchSize+1, maze.GenerateOptions{Seed: 1, Loops: 0.1})
+		if err != nil {
+			panic(err)
+		}
+		return m
+	})
+)
+
+// Solve the open and the generated benchmark mazes with the algorithms ordered by their frontier. Run with
+// go test -bench Solve -benchtime 3x ./solve, a solve takes seconds
+func BenchmarkSolve(b *testing.B) {
+	mazes := []struct {
+		name string
+		m    func() *maze.Maze
+	}{
+		{"open", benchOpen},
+		{"maze", benchMaze},
+	}
+
+	for _, bm := range mazes {
+		for _, algo := range []maze.Algo{maze.BFS, maze.DIJKSTRA, maze.ASTAR} {
+			b.Run(fmt.Sprintf("%s/%s", bm.name, algo), func(b *testing.B) {
+				m := bm.m()
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					solver, err := NewSolverForAlgo(algo, m, nil)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if _, err := solver.Solve(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// Solve a generated weighted benchSize+1 x benchSize+1 maze with Dijkstra and with the parallel Dijkstra. Run with
+// go test -bench ParallelDijkstra -benchtime 3x -cpu 1,4 ./solve
+func BenchmarkParallelDijkstra(b *testing.B) {
+	m, err := maze.Generate(benchSize+1, benchSize+1, maze.GenerateOptions{Seed: 1, Loops: 0.3, Weighted: true})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	solvers := []struct {
+		name string
+		new  func() Solver
+	}{
+		{"sequential", func() Solver { return NewDijkstraSolver(m) }},
+		{"parallel", func() Solver { return NewParallelDijkstraSolver(m) }},
+	}
+	for _, s := range solvers {
+		b.Run(s.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := s.new().Solve(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// Solve a generated size x size weighted maze with algo without the trace, and get the nodes expanded and the
+// allocations of the solve
+func expansionAllocs(tb testing.TB, algo maze.Algo, size int) (expanded int, allocs float64) {
+	tb.Helper()
+
+	m, err := maze.Generate(size, size, maze.GenerateOptions{Seed: 1, Loops: 0.3, Weighted: true})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	allocs = testing.AllocsPerRun(1, func() {
+		solver, err := NewSolverForAlgo(algo, m, nil, WithoutTrace())
+		if err != nil {
+			tb.Fatal(err)
+		}
+		result, err := solver.Solve()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		expanded = result.Expanded
+	})
+
+	return expanded, allocs
+}
+
+// An expansion allocates nothing once the buffers, arenas and maps of the search have grown: the neighbors, the
+// successors and the nodes of the search tree are not allocated one by one, so a solve makes less than one allocation
+// per expanded node, and even less on a larger maze
+func TestAllocsPerExpansion(t *testing.T) {
+	for _, algo := range AllAlgos {
+		smallExpanded, smallAllocs := expansionAllocs(t, algo, 101)
+		largeExpanded, largeAllocs := expansionAllocs(t, algo, 301)
+
+		small, large := smallAllocs/float64(smallExpanded), largeAllocs/float64(largeExpanded)
+		if large >= 1 || large > small {
+			t.Errorf("%s: %.2f allocations per expanded node on a 101x101 maze, %.2f on a 301x301 maze", algo, small, large)
+		}
+	}
+}
+
+// Report the allocations per expanded node of a solve of a generated weighted benchSize+1 x benchSize+1 maze. Run with
+// go test -bench Expansion -benchtime 1x ./solve
+func BenchmarkExpansionAllocs(b *testing.B) {
+	for _, algo := range append(slices.Clone(AllAlgos), maze.PARALLEL_DIJKSTRA) {
+		b.Run(string(algo), func(b *testing.B) {
+			var expanded int
+			var allocs float64
+			for range b.N {
+				expanded, allocs = expansionAllocs(b, algo, benchSize+1)
+			}
+			b.ReportMetric(allocs/float64(expanded), "allocs/expansion")
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package solve
+
+import (
+	"context"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// BFS implementation
+type BFSSolver struct {
+	gridSolver
+}
+
+// Constructor of BFS solver
+func NewBFSSolver(m *maze.Maze, opts ...Option) Solver {
+	return &BFSSolver{newGridSolver(m, maze.BFS, opts)}
+}
+
+// Solve maze using BFS
+func (bfs *BFSSolver) Solve() (maze.Result, error) {
+	return bfs.SolveContext(context.Background())
+}
+
+// Solve maze using BFS, stopping with the partial stats and the context error once ctx is done
+func (bfs *BFSSolver) SolveContext(ctx context.Context) (maze.Result, error) {
+	// Since this is BFS, we use FIFO. Unlike with DFS, we add all the neighbors into Frontier before moving to the next step
+	return bfs.solve(ctx, &Search[maze.Point]{Frontier: NewQueue[maze.Point]()})
+}
@@ -0,0 +1,164 @@
+package solve
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Every algorithm, in the order they are compared by default
+var AllAlgos = []maze.Algo{maze.DFS, maze.BFS, maze.DIJKSTRA, maze.GBFS, maze.ASTAR}
+
+// Options of CompareAll
+type CompareOptions struct {
+	Heuristic Heuristic     // Heuristic of GBFS and A*, nil means the default of each algorithm
+	Solver    []Option      // Options given to every solver
+	Timeout   time.Duration // Stop each solve after this long, 0 means no limit
+
+	// How many algorithms solve at the same time, 0 means all of them. The others wait for one to be done,
+	// OnSolved included, so it also bounds the memory of what OnSolved writes. Always 1 in deterministic mode
+	Parallelism int
+
+	// Called from the goroutine of each algorithm as soon as it's done, so its outputs can be written
+	// while the other algorithms are still solving
+	OnSolved func(c Comparison)
+
+	// Solve with each algorithm this many times, one run after the other, to measure the mean solve time. The result
+	// of the first run is kept, and the runs share the timeout. 0 or 1 means a single run
+	Runs int
+
+	// Set as the expand hook of every solver, with the algorithm of the solver. Algorithms solving at the same
+	// time call it from their own goroutines
+	OnExpand func(algo maze.Algo, ev ExpandEvent)
+}
+
+// The outcome of one algorithm
+type Comparison struct {
+	*maze.Solved
+	Err  error    // The error of the solve, the result is still recorded (e.g. ErrNoSolution, ErrLimitExceeded)
+	Runs RunStats // The solve times of every run, only set when CompareOptions.Runs is more than 1
+}
+
+// The outcome of every compared algorithm
+type ComparisonReport struct {
+	Results []Comparison // In the order the algorithms were given
+	Ranking []maze.Algo  // Best first: the cheapest solution, then the fewest expanded and generated nodes. Algorithms without a solution come last
+}
+
+// Solve m with every algorithm of algos, opts.Parallelism of them at the same time, and rank them. An error is only returned when the comparison
+// can't be run at all, the error of each solve is in its Comparison
+func CompareAll(m *maze.Maze, algos []maze.Algo, opts CompareOptions) (ComparisonReport, error) {
+	return CompareAllContext(context.Background(), m, algos, opts)
+}
+
+// Same as CompareAll, but every solve stops once ctx is done
+func CompareAllContext(ctx context.Context, m *maze.Maze, algos []maze.Algo, opts CompareOptions) (ComparisonReport, error) {
+	var report ComparisonReport
+	if err := m.Validate(); err != nil {
+		return report, err
+	}
+
+	solvers := make([]Solver, len(algos))
+	for i, algo := range algos {
+		solver, err := NewSolverForAlgo(algo, m, opts.Heuristic, opts.Solver...)
+		if err != nil {
+			return report, err
+		}
+		if opts.OnExpand != nil {
+			solver.OnExpand(func(ev ExpandEvent) { opts.OnExpand(algo, ev) })
+		}
+		solvers[i] = solver
+	}
+
+	// The solvers only read the maze, so they can share it. Each worker takes the next algorithm until none is left
+	report.Results = make([]Comparison, len(algos))
+	workers := len(solvers)
+	if opts.Parallelism > 0 {
+		workers = min(workers, opts.Parallelism)
+	}
+	// One algorithm at a time, in the given order, so the hooks and what they log don't depend on the scheduling
+	if maze.Deterministic {
+		workers = 1
+	}
+
+	jobs := make(chan int, len(solvers))
+	for i := range solvers {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg := sync.WaitGroup{}
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				report.Results[i] = compare(ctx, m, algos[i], solvers[i], opts)
+				if opts.OnSolved != nil {
+					opts.OnSolved(report.Results[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	report.Ranking = rank(report.Results)
+	return report, nil
+}
+
+// Solve m with the solver of algo, within the timeout of opts
+func compare(ctx context.Context, m *maze.Maze, algo maze.Algo, solver Solver, opts CompareOptions) Comparison {
+	var cancel context.CancelFunc
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	result, err := solver.SolveContext(ctx)
+	solved := &maze.Solved{Maze: m, SearchType: algo, Result: result}
+	if h := HeuristicOf(solver); h != nil {
+		solved.Heuristic = h.Name()
+	}
+
+	c := Comparison{Solved: solved, Err: err}
+	if opts.Runs > 1 && (err == nil || errors.Is(err, ErrNoSolution)) {
+		// A run that fails to create its solver would have failed the comparison already
+		c.Runs, _ = Repeat(ctx, m, algo, opts.Heuristic, opts.Solver, result, opts.Runs)
+	}
+
+	return c
+}
+
+// Rank the algorithms, best first. Ties keep the order of the results, so the ranking doesn't depend on the timing
+func rank(results []Comparison) []maze.Algo {
+	ranked := slices.Clone(results)
+	slices.SortStableFunc(ranked, func(a, b Comparison) int {
+		if solvedA, solvedB := a.Err == nil, b.Err == nil; solvedA != solvedB {
+			if solvedA {
+				return -1
+			}
+			return 1
+		}
+
+		if a.PathCost != b.PathCost {
+			return a.PathCost - b.PathCost
+		}
+		if a.Expanded != b.Expanded {
+			return a.Expanded - b.Expanded
+		}
+		return a.Generated - b.Generated
+	})
+
+	ranking := make([]maze.Algo, len(ranked))
+	for i, c := range ranked {
+		ranking[i] = c.SearchType
+	}
+
+	return ranking
+}
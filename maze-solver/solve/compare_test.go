@@ -0,0 +1,49 @@
+package solve
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// With a parallelism of 2, two algorithms solve at the same time, measuring their allocations or not: the first node
+// expanded by each one waits for the other one to expand its first node too
+func TestCompareAllOverlapsSolves(t *testing.T) {
+	m := loadTestMaze(t, "testdata/weighted/small.txt", maze.FourWay{})
+	algos := []maze.Algo{maze.BFS, maze.DFS}
+
+	for _, opts := range [][]Option{nil, {WithAllocStats()}} {
+		var once [2]sync.Once
+		started := [2]chan struct{}{make(chan struct{}), make(chan struct{})}
+		overlapped := make(chan bool, 2)
+
+		onExpand := func(algo maze.Algo, ev ExpandEvent) {
+			i := 0
+			if algo == algos[1] {
+				i = 1
+			}
+			once[i].Do(func() {
+				close(started[i])
+				select {
+				case <-started[1-i]:
+					overlapped <- true
+				case <-time.After(5 * time.Second):
+					overlapped <- false
+				}
+			})
+		}
+
+		_, err := CompareAll(m, algos, CompareOptions{Solver: opts, Parallelism: 2, OnExpand: onExpand})
+		if err != nil {
+			t.Fatal(err)
+		}
+		close(overlapped)
+		for ok := range overlapped {
+			if !ok {
+				t.Fatal("the algorithms have solved one after the other")
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package solve
+
+import (
+	"context"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Maze-solver using DFS
+type DFSSolver struct {
+	gridSolver
+}
+
+// Constructor of DFS Solver
+func NewDFSSolver(m *maze.Maze, opts ...Option) Solver {
+	return &DFSSolver{newGridSolver(m, maze.DFS, opts)}
+}
+
+// Solve maze
+func (dfs *DFSSolver) Solve() (maze.Result, error) {
+	return dfs.SolveContext(context.Background())
+}
+
+// Solve maze, stopping with the partial stats and the context error once ctx is done
+func (dfs *DFSSolver) SolveContext(ctx context.Context) (maze.Result, error) {
+	// Use LIFO since this is DFS. We only add the first unvisited neighbor and immediately move on the next step,
+	// and backtrack to a place that has new path to move when there is none
+	return dfs.solve(ctx, &Search[maze.Point]{Frontier: NewStack[maze.Point](), DepthFirst: true})
+}
@@ -0,0 +1,51 @@
+package solve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// DFS, BFS and A* stop with ErrNoSolution and an unreachable goal on the mazes of testdata/walled, whose goal is
+// walled off from the start. DFS used to backtrack past the start there, dereferencing its nil parent
+func TestWalledOffGoal(t *testing.T) {
+	paths, err := filepath.Glob("testdata/walled/*.txt")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no walled mazes in testdata: %v", err)
+	}
+
+	for _, path := range paths {
+		for _, tt := range testTopologies {
+			m := loadTestMaze(t, path, tt.topology)
+			for _, algo := range []maze.Algo{maze.DFS, maze.BFS, maze.ASTAR} {
+				name := strings.TrimSuffix(filepath.Base(path), ".txt")
+				t.Run(fmt.Sprintf("%s/%s/%s", name, tt.name, algo), func(t *testing.T) {
+					solver, err := NewSolverForAlgo(algo, m, nil)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					// A search that backtracks forever is stopped by the deadline
+					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					defer cancel()
+					result, err := solver.SolveContext(ctx)
+					if !errors.Is(err, ErrNoSolution) {
+						t.Fatalf("got error %v, want ErrNoSolution", err)
+					}
+					if !result.Unreachable {
+						t.Error("the goal isn't reported unreachable")
+					}
+					if len(result.Solution.Path) != 0 {
+						t.Errorf("got a solution %v", result.Solution.Path)
+					}
+				})
+			}
+		}
+	}
+}
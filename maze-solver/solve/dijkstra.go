@@ -0,0 +1,35 @@
+package solve
+
+import (
+	"context"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Dijkstra implementation
+type DijkstraSolver struct {
+	gridSolver
+}
+
+// Constructor of DijkstraSolver
+func NewDijkstraSolver(m *maze.Maze, opts ...Option) Solver {
+	return &DijkstraSolver{newGridSolver(m, maze.DIJKSTRA, opts)}
+}
+
+// Solve maze using Dijkstra
+func (d *DijkstraSolver) Solve() (maze.Result, error) {
+	return d.SolveContext(context.Background())
+}
+
+// Solve maze using Dijkstra, stopping with the partial stats and the context error once ctx is done
+func (d *DijkstraSolver) SolveContext(ctx context.Context) (maze.Result, error) {
+	// Expand the node with the smallest path cost first. A cheaper path to a node of the frontier moves the node onto it
+	// (decrease-key), and a cheaper path to an expanded node reopens it, so the path found is the cheapest one
+	return d.solve(ctx, &Search[maze.Point]{
+		Frontier: NewPriorityFrontier(costOrder()),
+		Cost: func(node *Node[maze.Point]) int {
+			return node.PathCost
+		},
+		Reopen: true,
+	})
+}
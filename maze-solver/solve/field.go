@@ -0,0 +1,97 @@
+package solve
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The exact cost from every square of a maze to its goal, computed once by a Dijkstra from the goal. As the heuristic of
+// A* it's perfect, only the squares of a cheapest path are expanded, and looking it up is cheaper than computing any
+// other heuristic. Computing it costs about as much as a Dijkstra solve, so it pays off when the same maze is solved
+// many times. It only knows the distances to the goal it was computed for, and can be shared by solvers running at the same time
+type DistanceField struct {
+	width int
+	goal  maze.Point
+	cost  []int // -1 for the walls and the squares that can't reach the goal
+}
+
+// The cost of a square that can't reach the goal, high enough to never be picked and low enough to not overflow a path cost
+const unreachable = math.MaxInt32
+
+// Compute the distance field of m to its goal
+func NewDistanceField(m *maze.Maze) (*DistanceField, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	f := &DistanceField{width: m.Width, goal: m.Goal, cost: make([]int, m.Height*m.Width)}
+	for i := range f.cost {
+		f.cost[i] = -1
+	}
+
+	// Every square is explored, so the search can only end without a solution
+	search := &Search[maze.Point]{
+		Graph:    reverseGrid{&Grid{Maze: m}},
+		Frontier: NewPriorityFrontier(ByCost[maze.Point]),
+		IsGoal: func(p maze.Point) bool {
+			return false
+		},
+		Cost: func(node *Node[maze.Point]) int {
+			return node.PathCost
+		},
+	}
+	if _, err := search.Run(context.Background(), m.Goal); !errors.Is(err, ErrNoSolution) {
+		return nil, err
+	}
+
+	for _, node := range search.Tree {
+		f.cost[node.State.Row*f.width+node.State.Col] = node.PathCost
+	}
+
+	return f, nil
+}
+
+func (f *DistanceField) Name() string { return "field" }
+
+// The cost from a square to the goal. 0 when to isn't the goal of the field, which keeps it admissible
+func (f *DistanceField) Estimate(from, to maze.Point) float64 {
+	if to != f.goal {
+		return 0
+	}
+
+	cost, ok := f.Cost(from)
+	if !ok {
+		return unreachable
+	}
+
+	return float64(cost)
+}
+
+// Get the cost of the cheapest path from p to the goal, false if the goal can't be reached from p
+func (f *DistanceField) Cost(p maze.Point) (int, bool) {
+	if p.Row < 0 || p.Col < 0 || p.Col >= f.width || p.Row*f.width+p.Col >= len(f.cost) {
+		return 0, false
+	}
+
+	cost := f.cost[p.Row*f.width+p.Col]
+	return cost, cost >= 0
+}
+
+// The maze graph with every move reversed, to search from the goal. Moving into a square costs the cost of that square,
+// so the reversed move costs the cost of the square it leaves. The topologies are symmetric, the neighbors don't change
+type reverseGrid struct {
+	*Grid
+}
+
+func (g reverseGrid) AppendSuccessors(p maze.Point, buf []Successor[maze.Point]) []Successor[maze.Point] {
+	n := len(buf)
+	buf = g.Grid.AppendSuccessors(p, buf)
+	for i := n; i < len(buf); i++ {
+		buf[i].Cost = g.Maze.Cost(p)
+	}
+
+	return buf
+}
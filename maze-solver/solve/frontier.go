@@ -0,0 +1,79 @@
+package solve
+
+// First in, first out frontier, used by BFS
+type Queue[S State] struct {
+	nodes  []*Node[S]
+	states map[S]bool
+}
+
+// Last in, first out frontier, used by DFS
+type Stack[S State] struct {
+	nodes  []*Node[S]
+	states map[S]bool
+}
+
+func NewQueue[S State]() *Queue[S] {
+	return &Queue[S]{states: map[S]bool{}}
+}
+
+func (q *Queue[S]) Push(node *Node[S]) {
+	q.nodes = append(q.nodes, node)
+	q.states[node.State] = true
+}
+
+func (q *Queue[S]) Pop() *Node[S] {
+	if len(q.nodes) == 0 {
+		return nil
+	}
+
+	// Since this is FIFO, we pull out the first element
+	node := q.nodes[0]
+	q.nodes = q.nodes[1:]
+	delete(q.states, node.State)
+	return node
+}
+
+func (q *Queue[S]) Len() int {
+	return len(q.nodes)
+}
+
+func (q *Queue[S]) Contains(state S) bool {
+	return q.states[state]
+}
+
+func (q *Queue[S]) Nodes() []*Node[S] {
+	return q.nodes
+}
+
+func NewStack[S State]() *Stack[S] {
+	return &Stack[S]{states: map[S]bool{}}
+}
+
+func (st *Stack[S]) Push(node *Node[S]) {
+	st.nodes = append(st.nodes, node)
+	st.states[node.State] = true
+}
+
+func (st *Stack[S]) Pop() *Node[S] {
+	if len(st.nodes) == 0 {
+		return nil
+	}
+
+	// Since this is LIFO, we remove the last element
+	node := st.nodes[len(st.nodes)-1]
+	st.nodes = st.nodes[:len(st.nodes)-1]
+	delete(st.states, node.State)
+	return node
+}
+
+func (st *Stack[S]) Len() int {
+	return len(st.nodes)
+}
+
+func (st *Stack[S]) Contains(state S) bool {
+	return st.states[state]
+}
+
+func (st *Stack[S]) Nodes() []*Node[S] {
+	return st.nodes
+}
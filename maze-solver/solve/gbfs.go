@@ -0,0 +1,46 @@
+package solve
+
+import (
+	"context"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Greedy Best First Search implementation
+type GBFSSolver struct {
+	gridSolver
+	Heuristic Heuristic // The estimate of the distance to the goal, which is the priority of the nodes
+}
+
+// GBFS Solver constructor, with the Manhattan distance as heuristic
+func NewGBFSSolver(m *maze.Maze, opts ...Option) Solver {
+	return NewGBFSSolverWithHeuristic(m, Manhattan{}, opts...)
+}
+
+// GBFS Solver constructor with a custom heuristic
+func NewGBFSSolverWithHeuristic(m *maze.Maze, h Heuristic, opts ...Option) Solver {
+	return &GBFSSolver{
+		gridSolver: newGridSolver(m, maze.GBFS, opts),
+		Heuristic:  h,
+	}
+}
+
+// Solve maze using GBFS
+func (gbfs *GBFSSolver) Solve() (maze.Result, error) {
+	return gbfs.SolveContext(context.Background())
+}
+
+// Solve maze using GBFS, stopping with the partial stats and the context error once ctx is done
+func (gbfs *GBFSSolver) SolveContext(ctx context.Context) (maze.Result, error) {
+	// Greedy Best First Search, is almost similar to how Dijkstra works, except on how it calculate the cost.
+	// In GBFS, we assume that the closest neighbor to the goal the local optimal point
+	return gbfs.solve(ctx, &Search[maze.Point]{
+		Frontier: NewPriorityFrontier(costOrder()),
+		Estimate: func(p maze.Point) int {
+			return estimate(gbfs.Heuristic, p, gbfs.Maze.Goal)
+		},
+		Cost: func(node *Node[maze.Point]) int {
+			return node.Estimate
+		},
+	})
+}
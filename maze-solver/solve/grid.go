@@ -0,0 +1,234 @@
+package solve
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The maze as a graph: the states are the open squares, and the moves follow the topology of the maze
+type Grid struct {
+	Maze *maze.Maze
+
+	neighbors []maze.Neighbor // Reused by every call of AppendSuccessors
+}
+
+func (g *Grid) AppendSuccessors(p maze.Point, buf []Successor[maze.Point]) []Successor[maze.Point] {
+	g.neighbors = g.Maze.AppendNeighbors(p, g.neighbors[:0])
+	for _, neighbor := range g.neighbors {
+		buf = append(buf, Successor[maze.Point]{
+			State:  neighbor.Point,
+			Action: string(neighbor.Action),
+			Cost:   g.Maze.Cost(neighbor.Point),
+		})
+	}
+
+	return buf
+}
+
+// The number of states of the grid: its open squares
+func (g *Grid) States() int {
+	return g.Maze.GetEmptySquares()
+}
+
+// What every maze solver is built on: run the generic search on the maze grid, and record it into the result.
+// The maze is only read, so it can be shared by solvers running at the same time
+type gridSolver struct {
+	Maze *maze.Maze
+
+	hooks
+	options
+
+	algo     maze.Algo
+	search   *Search[maze.Point] // The last search, kept for Snapshot
+	result   maze.Result         // What the last search has recorded, kept for Snapshot
+	finished bool                // Whether the last search has finished, so there is nothing to carry on
+	resume   *snapshot           // The snapshot the next solve carries on from, set by Resume
+	steps    int                 // Steps reported by the last solve when the trace isn't recorded
+	lastStep maze.Point          // The last step of the last solve when the trace isn't recorded
+}
+
+func newGridSolver(m *maze.Maze, algo maze.Algo, opts []Option) gridSolver {
+	g := gridSolver{Maze: m, algo: algo}
+	for _, opt := range opts {
+		opt(&g.options)
+	}
+
+	return g
+}
+
+// The most steps of the trace allocated before solving, so a search that stops early on a huge maze doesn't allocate
+// for the whole maze
+const maxPreallocSteps = 1 << 20
+
+// The order of the priority frontiers: by cost, with ties broken in a fixed order in deterministic mode
+func costOrder() func(a, b *Node[maze.Point]) bool {
+	if maze.Deterministic {
+		return ByCostThenOrder[maze.Point]
+	}
+
+	return ByCost[maze.Point]
+}
+
+// Solve the maze with search, which only has to be given the frontier and the cost function of the algorithm
+func (g *gridSolver) solve(ctx context.Context, search *Search[maze.Point]) (result maze.Result, err error) {
+	defer func() {
+		g.done(result, err)
+	}()
+
+	g.search, g.finished = nil, false
+	if err := g.Maze.Validate(); err != nil {
+		return result, err
+	}
+
+	// The maze nodes of the expanded search nodes, allocated by chunks like the search nodes. They are in the order of
+	// the tree of the search, so the maze node of a parent is found at its position in the tree
+	var chunk []maze.Node
+
+	search.Graph = &Grid{Maze: g.Maze}
+	search.MaxNodes = g.maxNodes
+	search.MaxDuration = g.maxDuration
+	search.IsGoal = func(p maze.Point) bool {
+		return p == g.Maze.Goal
+	}
+	addNode := func(node *Node[maze.Point]) *maze.Node {
+		if len(chunk) == 0 {
+			chunk = make([]maze.Node, arenaChunk)
+		}
+		expanded := &chunk[0]
+		chunk = chunk[1:]
+
+		*expanded = maze.Node{
+			Square: g.Maze.Square(node.State),
+			Action: maze.Action(node.Action),
+			Cost:   node.Cost,
+		}
+		if node.Parent == nil {
+			expanded.Action = maze.NONE
+		} else {
+			expanded.Parent = result.SearchTree[node.Parent.tree]
+		}
+
+		result.SearchTree = append(result.SearchTree, expanded)
+		return expanded
+	}
+	search.OnExpand = func(node *Node[maze.Point], frontier, explored int) {
+		g.expanded(addNode(node), node.PathCost, node.Estimate, frontier, explored)
+	}
+	search.OnPush = func(node *Node[maze.Point], frontier int) {
+		if !g.noTrace {
+			result.Enqueued = append(result.Enqueued, node.State)
+		}
+		g.emit(SearchEvent{Kind: EventEnqueue, Point: node.State, Cost: node.Cost, PathCost: node.PathCost,
+			Estimate: node.Estimate, Frontier: frontier, Explored: len(search.Explored)})
+	}
+	g.steps, g.lastStep = 0, maze.Point{Row: -1, Col: -1}
+	search.OnStep = func(p maze.Point, frontier int) {
+		result.Explored = search.Explored
+		g.step(&result, p, frontier)
+	}
+
+	stop := measure(&result, g.allocStats)
+
+	var goal *Node[maze.Point]
+	if snap := g.resume; snap != nil {
+		// Carry on from the snapshot, with what has been recorded before it
+		g.resume = nil
+		if err := search.Restore(snap.Search); err != nil {
+			stop()
+			return result, err
+		}
+		for _, node := range search.Tree {
+			addNode(node)
+		}
+		result.ExperimentPath = snap.ExperimentPath
+		result.Counters = snap.Counters
+		result.Enqueued = snap.Enqueued
+		result.Stats = snap.Stats
+		result.Explored = search.Explored
+
+		goal, err = search.Continue(ctx)
+	} else {
+		g.allocTrace(&result)
+		goal, err = search.Run(ctx, g.Maze.Start)
+	}
+
+	if err == nil {
+		actions, path := Backtrack(goal)
+		result.Solution.Path = path
+		for _, action := range actions {
+			result.Solution.Actions = append(result.Solution.Actions, maze.Action(action))
+		}
+	}
+
+	// A resumed solve adds to the time and allocations recorded before the snapshot
+	stop()
+
+	result.Explored = search.Explored
+	result.Expanded = len(result.SearchTree)
+	result.Generated = search.Generated
+	result.FrontierPeak = search.FrontierPeak
+	result.PathLength = len(result.Solution.Path)
+	result.PathCost = g.Maze.CostOf(result.Solution.Path)
+	result.Unreachable = errors.Is(err, ErrNoSolution)
+
+	g.search, g.result = search, result
+	g.finished = err == nil || errors.Is(err, ErrNoSolution)
+	return result, err
+}
+
+// Allocate the trace of a solve that starts over, unless it isn't recorded
+func (g *gridSolver) allocTrace(result *maze.Result) {
+	if g.noTrace {
+		return
+	}
+
+	// A search steps at least once per expanded square, which is at most every open square of the maze
+	size := min(g.Maze.GetEmptySquares(), maxPreallocSteps)
+	result.ExperimentPath = make([]maze.Point, 0, size)
+	result.Counters = make([]maze.StepCounter, 0, size)
+}
+
+// Record a step into result, whose Explored must be up to date. When the trace isn't recorded, the step is only
+// reported to the step hook, like RecordStep would
+func (g *gridSolver) step(result *maze.Result, p maze.Point, frontier int) {
+	if !g.noTrace {
+		result.RecordStep(p, frontier, g.onStep)
+		return
+	}
+
+	if g.onStep != nil && p != g.lastStep {
+		g.onStep(g.steps, p, maze.StepCounter{Frontier: frontier, Explored: len(result.Explored)})
+		g.steps++
+	}
+	g.lastStep = p
+}
+
+// Start measuring the time of a solve, and its allocations too with allocs. The returned function adds them to the
+// stats of result. They change on every run, so they are not measured in deterministic mode
+func measure(result *maze.Result, allocs bool) func() {
+	if maze.Deterministic {
+		return func() {}
+	}
+
+	var before runtime.MemStats
+	if allocs {
+		runtime.ReadMemStats(&before)
+	}
+	start := time.Now()
+
+	return func() {
+		result.SolveTime += time.Since(start)
+		if !allocs {
+			return
+		}
+
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		result.BytesAlloc += after.TotalAlloc - before.TotalAlloc
+		result.Allocs += after.Mallocs - before.Mallocs
+	}
+}
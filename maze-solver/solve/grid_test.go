@@ -0,0 +1,79 @@
+package solve
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Solvers of every algorithm share one maze while running at the same time, and find the same solution they find
+// alone. Run with go test -race to catch a write to the shared maze
+func TestConcurrentSolvers(t *testing.T) {
+	for _, tt := range testTopologies {
+		t.Run(tt.name, func(t *testing.T) {
+			m := loadTestMaze(t, "testdata/weighted/field.txt", tt.topology)
+			rows := m.Rows()
+
+			field, err := NewDistanceField(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Every algorithm measuring its allocations, and A* with the distance field, which its solvers share too
+			solvers := map[string]func() (Solver, error){}
+			for _, algo := range append(slices.Clone(AllAlgos), maze.PARALLEL_DIJKSTRA) {
+				solvers[string(algo)] = func() (Solver, error) { return NewSolverForAlgo(algo, m, nil, WithWorkers(2), WithAllocStats()) }
+			}
+			solvers["field"] = func() (Solver, error) { return NewAStarSolverWithHeuristic(m, field), nil }
+
+			alone := map[string]maze.Result{}
+			for name, newSolver := range solvers {
+				solver, err := newSolver()
+				if err != nil {
+					t.Fatal(err)
+				}
+				alone[name] = solveValid(t, solver, m)
+			}
+
+			// Each solver twice at the same time
+			var wg sync.WaitGroup
+			errs := make(chan error, 2*len(solvers))
+			for name, newSolver := range solvers {
+				for range 2 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+
+						solver, err := newSolver()
+						if err != nil {
+							errs <- fmt.Errorf("%s: %v", name, err)
+							return
+						}
+						result, err := solver.Solve()
+						if err == nil {
+							err = maze.VerifyResult(m, result)
+						}
+						switch {
+						case err != nil:
+							errs <- fmt.Errorf("%s: %v", name, err)
+						case result.PathCost != alone[name].PathCost:
+							errs <- fmt.Errorf("%s: path costs %d, %d when solving alone", name, result.PathCost, alone[name].PathCost)
+						}
+					}()
+				}
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Error(err)
+			}
+			if !slices.Equal(rows, m.Rows()) {
+				t.Error("the shared maze has been modified")
+			}
+		})
+	}
+}
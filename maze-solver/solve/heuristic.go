@@ -0,0 +1,143 @@
+package solve
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Estimate of the cost from a square to the goal, used by GBFS and A* to pick which node to expand first
+type Heuristic interface {
+	Name() string
+	Estimate(from, to maze.Point) float64
+}
+
+// The sum of rows and columns to go, exact on an empty 4-connected grid
+type Manhattan struct{}
+
+func (Manhattan) Name() string { return "manhattan" }
+
+func (Manhattan) Estimate(from, to maze.Point) float64 {
+	return float64(maze.Abs(to.Row-from.Row) + maze.Abs(to.Col-from.Col))
+}
+
+// The straight line distance
+type Euclidean struct{}
+
+func (Euclidean) Name() string { return "euclidean" }
+
+func (Euclidean) Estimate(from, to maze.Point) float64 {
+	return math.Hypot(float64(to.Row-from.Row), float64(to.Col-from.Col))
+}
+
+// The larger of the rows and columns to go, exact on an empty grid where diagonal moves cost the same as straight ones
+type Chebyshev struct{}
+
+func (Chebyshev) Name() string { return "chebyshev" }
+
+func (Chebyshev) Estimate(from, to maze.Point) float64 {
+	return float64(max(maze.Abs(to.Row-from.Row), maze.Abs(to.Col-from.Col)))
+}
+
+// Exact on an empty grid where diagonal moves cost sqrt(2)
+type Octile struct{}
+
+func (Octile) Name() string { return "octile" }
+
+func (Octile) Estimate(from, to maze.Point) float64 {
+	dr, dc := float64(maze.Abs(to.Row-from.Row)), float64(maze.Abs(to.Col-from.Col))
+	return max(dr, dc) + (math.Sqrt2-1)*min(dr, dc)
+}
+
+// Always 0, which turns A* into Dijkstra and GBFS into an uninformed search
+type Zero struct{}
+
+func (Zero) Name() string { return "zero" }
+
+func (Zero) Estimate(from, to maze.Point) float64 {
+	return 0
+}
+
+// Get the default heuristic of A* with topology (nil means FourWay): the tightest of the built-in heuristics that is
+// admissible with it, Manhattan on FourWay and Chebyshev on EightWay. Other topologies get Zero, which is always
+// admissible
+func DefaultHeuristic(topology maze.Topology) Heuristic {
+	switch topology.(type) {
+	case nil, maze.FourWay:
+		return Manhattan{}
+	case maze.EightWay:
+		return Chebyshev{}
+	}
+
+	return Zero{}
+}
+
+// Get the estimate of h as the integer cost of the search. It's rounded up: the costs of the moves are integers, so the
+// estimate stays admissible and consistent when h is, and is tighter than when truncated. The tolerance keeps exact
+// estimates computed with a float error (like a whole number from the square root of Euclidean) from being rounded up
+func estimate(h Heuristic, from, to maze.Point) int {
+	return int(math.Ceil(h.Estimate(from, to) - 1e-9))
+}
+
+// Get the heuristic of a solver, nil if the solver doesn't use one
+func HeuristicOf(solver Solver) Heuristic {
+	switch s := solver.(type) {
+	case *GBFSSolver:
+		return s.Heuristic
+	case *AStarSolver:
+		return s.Heuristic
+	}
+
+	return nil
+}
+
+// Check if a heuristic never overestimates the cost to the goal when moving with topology (nil means FourWay), which is
+// what A* needs to find the shortest path. Every square costs at least 1 and a diagonal move costs the same as a straight
+// one, so on EightWay only Chebyshev and Zero are admissible. Heuristics and topologies other than the built-in ones are
+// reported as not admissible since they can't be checked, except a DistanceField which is exact with the topology it was
+// computed with
+func Admissible(h Heuristic, topology maze.Topology) bool {
+	if _, ok := h.(*DistanceField); ok {
+		return true
+	}
+
+	switch topology.(type) {
+	case nil, maze.FourWay:
+		switch h.(type) {
+		case Manhattan, Euclidean, Chebyshev, Octile, Zero:
+			return true
+		}
+	case maze.EightWay:
+		switch h.(type) {
+		case Chebyshev, Zero:
+			return true
+		}
+	}
+
+	return false
+}
+
+var heuristics = []Heuristic{Manhattan{}, Euclidean{}, Chebyshev{}, Octile{}, Zero{}}
+
+// Get the names of the heuristics HeuristicByName knows
+func HeuristicNames() []string {
+	names := make([]string, len(heuristics))
+	for i, h := range heuristics {
+		names[i] = h.Name()
+	}
+
+	return names
+}
+
+// Get a heuristic by its name
+func HeuristicByName(name string) (Heuristic, error) {
+	for _, h := range heuristics {
+		if h.Name() == strings.ToLower(name) {
+			return h, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown heuristic %q, supported: %s", name, strings.Join(HeuristicNames(), ", "))
+}
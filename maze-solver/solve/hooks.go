@@ -0,0 +1,94 @@
+package solve
+
+import "github.com/danglnh07/go-ai/maze-solver/maze"
+
+// Reported every time a solver expands a node, which is when the node is taken out of the frontier
+type ExpandEvent struct {
+	Node     *maze.Node // The expanded node
+	Frontier int        // Number of nodes left in the frontier
+	Explored int        // Number of nodes expanded so far, including this one
+}
+
+// What a SearchEvent reports
+type EventKind string
+
+const (
+	EventExpand  EventKind = "expand"  // A node is taken out of the frontier
+	EventEnqueue EventKind = "enqueue" // A node is added to the frontier
+	EventDone    EventKind = "done"    // The solve has returned, this is the last event
+)
+
+// Sent on the channel of Events while a solver is solving
+type SearchEvent struct {
+	Kind     EventKind
+	Point    maze.Point // The square of the expanded or enqueued node, unset for EventDone
+	Cost     int        // The cost ordering the node in the frontier, like maze.Node.Cost. Unset for EventDone
+	PathCost int        // Cost of the path from the start to the node (g). Unset for EventDone
+	Estimate int        // Estimated cost from the node to the goal (h), 0 for the algorithms without heuristic
+	Frontier int        // Number of nodes in the frontier after the event
+	Explored int        // Number of nodes expanded so far
+	Stats    maze.Stats // The statistics of the solve, only set for EventDone
+	Err      error      // The error returned by the solve, only set for EventDone
+}
+
+// Optional callbacks of a solver. Every solver embeds it, so they are set the same way on all of them
+type hooks struct {
+	onExpand func(ev ExpandEvent)
+	onStep   maze.StepHook
+	events   chan SearchEvent
+}
+
+// How many events can wait on the channel of Events before the solver waits for them to be read
+const eventBuffer = 256
+
+// Get the channel of the events of the next solve, which is closed after its EventDone. The solver waits when the
+// channel is full, so it must be read until it's closed. Calling it again before the solve returns the same channel
+func (h *hooks) Events() <-chan SearchEvent {
+	if h.events == nil {
+		h.events = make(chan SearchEvent, eventBuffer)
+	}
+
+	return h.events
+}
+
+// Set the callback called every time a node is expanded, so progress can be shown while solving
+func (h *hooks) OnExpand(hook func(ev ExpandEvent)) {
+	h.onExpand = hook
+}
+
+// Set the callback called on every step the solver takes, including the backtracking steps of DFS
+func (h *hooks) OnStep(hook maze.StepHook) {
+	h.onStep = hook
+}
+
+// Report an expanded node, with the cost of its path and its estimate, to the expand hook if set
+func (h *hooks) expanded(node *maze.Node, pathCost, estimate, frontier, explored int) {
+	if h.onExpand != nil {
+		h.onExpand(ExpandEvent{Node: node, Frontier: frontier, Explored: explored})
+	}
+	h.emit(SearchEvent{Kind: EventExpand, Point: node.Square.Coordinate, Cost: node.Cost, PathCost: pathCost,
+		Estimate: estimate, Frontier: frontier, Explored: explored})
+}
+
+// Send an event if Events has been called
+func (h *hooks) emit(ev SearchEvent) {
+	if h.events != nil {
+		h.events <- ev
+	}
+}
+
+// Send the EventDone of a solve and close the channel, the next solve needs a new call to Events
+func (h *hooks) done(result maze.Result, err error) {
+	if h.events == nil {
+		return
+	}
+
+	ev := SearchEvent{Kind: EventDone, Explored: result.Expanded, Stats: result.Stats, Err: err}
+	if len(result.Counters) > 0 {
+		ev.Frontier = result.Counters[len(result.Counters)-1].Frontier
+	}
+
+	h.events <- ev
+	close(h.events)
+	h.events = nil
+}
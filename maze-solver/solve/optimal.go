@@ -0,0 +1,30 @@
+package solve
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Get the cost of the cheapest path from the start to the goal, and the algorithm it was found with: BFS when every
+// open square costs 1, Dijkstra otherwise. Both always find an optimal path on such a maze, which makes the cost the
+// ground truth the other algorithms are checked against. ErrNoSolution is returned when the goal can't be reached
+func OptimalCost(ctx context.Context, m *maze.Maze) (int, maze.Algo, error) {
+	algo := maze.BFS
+	if m.Weighted() {
+		algo = maze.DIJKSTRA
+	}
+
+	solver, err := NewSolverForAlgo(algo, m, nil, WithoutTrace())
+	if err != nil {
+		return 0, algo, err
+	}
+
+	result, err := solver.SolveContext(ctx)
+	if err != nil && !errors.Is(err, ErrNoSolution) {
+		return 0, algo, err
+	}
+
+	return result.PathCost, algo, err
+}
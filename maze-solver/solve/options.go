@@ -0,0 +1,69 @@
+package solve
+
+import (
+	"fmt"
+	"time"
+)
+
+// Optional setting of a solver, given to its constructor
+type Option func(o *options)
+
+type options struct {
+	maxNodes    int
+	maxDuration time.Duration
+	noTrace     bool
+	workers     int
+	allocStats  bool
+}
+
+// Stop the search with ErrLimitExceeded once n nodes have been expanded. 0 means no limit
+func WithMaxNodes(n int) Option {
+	return func(o *options) {
+		o.maxNodes = n
+	}
+}
+
+// Stop the search with ErrLimitExceeded once it has run for d. 0 means no limit
+func WithMaxDuration(d time.Duration) Option {
+	return func(o *options) {
+		o.maxDuration = d
+	}
+}
+
+// Don't record the ExperimentPath and Counters of the trace, which are only needed to animate the solving. The step hook
+// is still called. The search tree is still recorded
+func WithoutTrace() Option {
+	return func(o *options) {
+		o.noTrace = true
+	}
+}
+
+// Expand the nodes with n goroutines, only used by the parallel Dijkstra. 0 means one per CPU
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// Measure the heap allocations of the solve into BytesAlloc and Allocs. The Go runtime only counts them for the whole
+// process, so they are only exact when nothing else runs during the solve, like in a benchmark
+func WithAllocStats() Option {
+	return func(o *options) {
+		o.allocStats = true
+	}
+}
+
+// Returned when the search is stopped by the limit set with WithMaxNodes or WithMaxDuration.
+// The result returned with it holds what the solver has recorded until then
+type ErrLimitExceeded struct {
+	MaxNodes    int           // The node limit that was hit, 0 if it's the duration limit
+	MaxDuration time.Duration // The duration limit that was hit, 0 if it's the node limit
+}
+
+func (err ErrLimitExceeded) Error() string {
+	if err.MaxNodes > 0 {
+		return fmt.Sprintf("search stopped after expanding %d nodes, the node limit", err.MaxNodes)
+	}
+
+	return fmt.Sprintf("search stopped after running for %s, the duration limit", err.MaxDuration)
+}
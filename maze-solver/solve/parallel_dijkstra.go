@@ -0,0 +1,210 @@
+package solve
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Dijkstra that expands the nodes of the same path cost at the same time, with several goroutines.
+// The frontier is a list of buckets, one per path cost (delta-stepping with a bucket width of 1). Every square costs
+// at least 1, so a node can't lower the cost of a node in its own bucket: once the cheapest bucket is taken, all of
+// its nodes have their final cost and can be expanded together. The successors are then applied one worker after the
+// other, and the nodes of a bucket are expanded in row-major order, so the result doesn't depend on the timing.
+// It finds a path of the same cost as DijkstraSolver, but it can't be snapshotted
+type ParallelDijkstraSolver struct {
+	gridSolver
+}
+
+// Constructor of ParallelDijkstraSolver, WithWorkers sets how many goroutines expand a bucket
+func NewParallelDijkstraSolver(m *maze.Maze, opts ...Option) Solver {
+	return &ParallelDijkstraSolver{newGridSolver(m, maze.PARALLEL_DIJKSTRA, opts)}
+}
+
+// The fewest nodes a goroutine expands, so a small bucket isn't split into goroutines that cost more than they do
+const minNodesPerWorker = 64
+
+// The cheapest known way to reach a square
+type tentative struct {
+	cost   int // 0 when the square hasn't been reached
+	parent *maze.Node
+	action maze.Action
+}
+
+// A successor found by a worker, which is applied once every worker is done
+type relaxation struct {
+	point maze.Point
+	tentative
+}
+
+// Solve maze using the parallel Dijkstra
+func (d *ParallelDijkstraSolver) Solve() (maze.Result, error) {
+	return d.SolveContext(context.Background())
+}
+
+// Solve maze using the parallel Dijkstra, stopping with the partial stats and the context error once ctx is done
+func (d *ParallelDijkstraSolver) SolveContext(ctx context.Context) (result maze.Result, err error) {
+	defer func() {
+		d.done(result, err)
+	}()
+
+	d.search, d.finished = nil, false
+	m := d.Maze
+	if err := m.Validate(); err != nil {
+		return result, err
+	}
+
+	workers := d.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	index := func(p maze.Point) int {
+		return p.Row*m.Width + p.Col
+	}
+	best := make([]tentative, m.Height*m.Width)
+	settled := make([]bool, m.Height*m.Width)
+
+	// buckets[c] holds the squares reached with a path cost of c. A square is added again when a cheaper path is
+	// found, its older entry is dropped when its bucket is taken. pending counts the entries of every bucket
+	buckets := [][]maze.Point{{m.Start}}
+	pending := 1
+	result.Generated, result.FrontierPeak = 1, 1
+
+	d.allocTrace(&result)
+	if !d.noTrace {
+		result.Enqueued = append(result.Enqueued, m.Start)
+	}
+	d.steps, d.lastStep = 0, maze.Point{Row: -1, Col: -1}
+	d.step(&result, m.Start, pending)
+
+	stop := measure(&result, d.allocStats)
+	began := time.Now()
+
+	var goal *maze.Node
+	err = func() error {
+		for cost := 0; cost < len(buckets); cost++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			// Take the bucket, and keep the squares whose cheapest path is in it
+			bucket := buckets[cost]
+			buckets[cost] = nil
+			pending -= len(bucket)
+			nodes := make([]*maze.Node, 0, len(bucket))
+			slices.SortFunc(bucket, func(a, b maze.Point) int {
+				return cmp.Or(cmp.Compare(a.Row, b.Row), cmp.Compare(a.Col, b.Col))
+			})
+			for _, p := range bucket {
+				if settled[index(p)] || best[index(p)].cost != cost {
+					continue
+				}
+
+				if d.maxNodes > 0 && len(result.SearchTree) >= d.maxNodes {
+					return ErrLimitExceeded{MaxNodes: d.maxNodes}
+				}
+				if d.maxDuration > 0 && time.Since(began) >= d.maxDuration {
+					return ErrLimitExceeded{MaxDuration: d.maxDuration}
+				}
+
+				// Expand the square
+				t := best[index(p)]
+				node := &maze.Node{Square: m.Square(p), Parent: t.parent, Action: t.action, Cost: cost}
+				if node.Parent == nil {
+					node.Action = maze.NONE
+				}
+				settled[index(p)] = true
+				result.SearchTree = append(result.SearchTree, node)
+				d.expanded(node, cost, 0, pending, len(result.Explored)+1)
+				result.Explored = append(result.Explored, p)
+				d.step(&result, p, pending)
+
+				if p == m.Goal {
+					goal = node
+					return nil
+				}
+				nodes = append(nodes, node)
+			}
+
+			// Expand the bucket with the workers, each finds the successors of a part of it that are cheaper than
+			// what was known before the bucket. The costs are only read until every worker is done
+			parts := min(workers, (len(nodes)+minNodesPerWorker-1)/minNodesPerWorker)
+			found := make([][]relaxation, parts)
+			wg := sync.WaitGroup{}
+			for w := range parts {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var neighbors []maze.Neighbor
+					for _, node := range nodes[w*len(nodes)/parts : (w+1)*len(nodes)/parts] {
+						neighbors = m.AppendNeighbors(node.Square.Coordinate, neighbors[:0])
+						for _, next := range neighbors {
+							p := next.Point
+							t := best[index(p)]
+							c := cost + m.Cost(p)
+							if !settled[index(p)] && (t.cost == 0 || c < t.cost) {
+								found[w] = append(found[w], relaxation{point: p, tentative: tentative{cost: c, parent: node, action: next.Action}})
+							}
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			// Apply the successors in the order of the bucket, the first of the cheapest paths to a square is kept
+			for _, relaxations := range found {
+				for _, r := range relaxations {
+					if t := best[index(r.point)]; t.cost != 0 && t.cost <= r.cost {
+						continue
+					}
+
+					best[index(r.point)] = r.tentative
+					for len(buckets) <= r.cost {
+						buckets = append(buckets, nil)
+					}
+					buckets[r.cost] = append(buckets[r.cost], r.point)
+					pending++
+					result.Generated++
+					result.FrontierPeak = max(result.FrontierPeak, pending)
+					if !d.noTrace {
+						result.Enqueued = append(result.Enqueued, r.point)
+					}
+					d.emit(SearchEvent{Kind: EventEnqueue, Point: r.point, Cost: r.cost, PathCost: r.cost, Frontier: pending,
+						Explored: len(result.Explored)})
+				}
+			}
+		}
+
+		return ErrNoSolution
+	}()
+
+	if goal != nil {
+		for node := goal; node.Parent != nil; node = node.Parent {
+			result.Solution.Path = append(result.Solution.Path, node.Square.Coordinate)
+			result.Solution.Actions = append(result.Solution.Actions, node.Action)
+		}
+		slices.Reverse(result.Solution.Path)
+		slices.Reverse(result.Solution.Actions)
+	}
+
+	stop()
+
+	result.Expanded = len(result.SearchTree)
+	result.PathLength = len(result.Solution.Path)
+	result.PathCost = m.CostOf(result.Solution.Path)
+	result.Unreachable = errors.Is(err, ErrNoSolution)
+	return result, err
+}
+
+// The parallel Dijkstra keeps no search to carry on, so it can't be resumed
+func (d *ParallelDijkstraSolver) Resume(snapshot []byte) error {
+	return fmt.Errorf("%s can't be resumed from a snapshot", maze.PARALLEL_DIJKSTRA)
+}
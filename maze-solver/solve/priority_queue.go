@@ -0,0 +1,192 @@
+package solve
+
+// Binary min-heap of any item type, ordered by a pluggable less function. Items with several keys (e.g. LPA*'s two-key
+// priorities) only need a less function comparing them one after the other
+type PriorityQueue[T any] struct {
+	items    []T
+	less     func(a, b T) bool // Whether a comes out before b
+	setIndex func(item T, i int)
+}
+
+// Create an empty priority queue ordered by less
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Create an empty priority queue ordered by less, which calls setIndex every time an item moves, with its index in the
+// queue (-1 once it's popped). The index is what Fix needs to reorder an item after its priority has changed
+func NewIndexedPriorityQueue[T any](less func(a, b T) bool, setIndex func(item T, i int)) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less, setIndex: setIndex}
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Add an item to the queue
+func (pq *PriorityQueue[T]) Push(item T) {
+	pq.items = append(pq.items, item)
+	if pq.setIndex != nil {
+		pq.setIndex(item, len(pq.items)-1)
+	}
+	pq.up(len(pq.items) - 1)
+}
+
+// Remove and return the item that comes first. The queue must not be empty
+func (pq *PriorityQueue[T]) Pop() T {
+	n := len(pq.items) - 1
+	pq.swap(0, n)
+	pq.down(0, n)
+
+	item := pq.items[n]
+	if pq.setIndex != nil {
+		pq.setIndex(item, -1)
+	}
+	var zero T
+	pq.items[n] = zero // avoid memory leak
+	pq.items = pq.items[:n]
+	return item
+}
+
+// Return the item that comes first without removing it. The queue must not be empty
+func (pq *PriorityQueue[T]) Peek() T {
+	return pq.items[0]
+}
+
+// Restore the heap order after the items have been changed directly
+func (pq *PriorityQueue[T]) Init() {
+	n := len(pq.items)
+	for i := n/2 - 1; i >= 0; i-- {
+		pq.down(i, n)
+	}
+	if pq.setIndex != nil {
+		for i, item := range pq.items {
+			pq.setIndex(item, i)
+		}
+	}
+}
+
+// Move the item at i to its place after its priority has changed, in O(log n)
+func (pq *PriorityQueue[T]) Fix(i int) {
+	if !pq.down(i, len(pq.items)) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	if pq.setIndex != nil {
+		pq.setIndex(pq.items[i], i)
+		pq.setIndex(pq.items[j], j)
+	}
+}
+
+// Move the item at j up until its parent comes before it
+func (pq *PriorityQueue[T]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !pq.less(pq.items[j], pq.items[i]) {
+			break
+		}
+		pq.swap(i, j)
+		j = i
+	}
+}
+
+// Move the item at i0 down until both its children come after it, looking at the first n items only.
+// Return whether it has moved
+func (pq *PriorityQueue[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && pq.less(pq.items[j2], pq.items[j1]) {
+			j = j2 // right child
+		}
+		if !pq.less(pq.items[j], pq.items[i]) {
+			break
+		}
+		pq.swap(i, j)
+		i = j
+	}
+
+	return i > i0
+}
+
+// Order nodes on their cost, the lowest first. The default order of a PriorityFrontier
+func ByCost[S State](a, b *Node[S]) bool {
+	return a.Cost < b.Cost
+}
+
+// Order nodes on their cost, and nodes of the same cost in the order they were generated.
+// Unlike ByCost, the order of ties doesn't depend on how the priority queue is implemented
+func ByCostThenOrder[S State](a, b *Node[S]) bool {
+	if a.Cost != b.Cost {
+		return a.Cost < b.Cost
+	}
+
+	return a.Order < b.Order
+}
+
+// Frontier that always gives back the node that comes first in its order, used by Dijkstra, GBFS and A*.
+// The cost of its nodes can be lowered with Update
+type PriorityFrontier[S State] struct {
+	queue  *PriorityQueue[*Node[S]]
+	states map[S]*Node[S]
+}
+
+// Create a priority frontier ordered by less, or by cost if less is nil
+func NewPriorityFrontier[S State](less func(a, b *Node[S]) bool) *PriorityFrontier[S] {
+	if less == nil {
+		less = ByCost[S]
+	}
+
+	return &PriorityFrontier[S]{
+		queue:  NewIndexedPriorityQueue(less, func(node *Node[S], i int) { node.index = i }),
+		states: map[S]*Node[S]{},
+	}
+}
+
+// Add a node in O(log n), it's only moved up to its place in the heap
+func (pf *PriorityFrontier[S]) Push(node *Node[S]) {
+	pf.queue.Push(node)
+	pf.states[node.State] = node
+}
+
+func (pf *PriorityFrontier[S]) Pop() *Node[S] {
+	if pf.queue.Len() == 0 {
+		return nil
+	}
+
+	node := pf.queue.Pop()
+	delete(pf.states, node.State)
+	return node
+}
+
+func (pf *PriorityFrontier[S]) Len() int {
+	return pf.queue.Len()
+}
+
+func (pf *PriorityFrontier[S]) Contains(state S) bool {
+	return pf.states[state] != nil
+}
+
+// Get the node of state in the frontier, nil if there is none
+func (pf *PriorityFrontier[S]) Get(state S) *Node[S] {
+	return pf.states[state]
+}
+
+// Change the cost of a node of the frontier and move it to its new place, in O(log n)
+func (pf *PriorityFrontier[S]) Update(node *Node[S], cost int) {
+	node.Cost = cost
+	pf.queue.Fix(node.index)
+}
+
+// The nodes in heap order. Since every prefix of a heap is also a heap, pushing them back in this order keeps them
+// where they are
+func (pf *PriorityFrontier[S]) Nodes() []*Node[S] {
+	return pf.queue.items
+}
@@ -0,0 +1,70 @@
+package solve
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The solve times of one algorithm solving one maze several times
+type RunStats struct {
+	Times    []time.Duration // The solve time of every run, the first one included
+	SamePath bool            // Every run has found the same path as the first one
+}
+
+// Get the mean solve time of the runs
+func (r RunStats) Mean() time.Duration {
+	if len(r.Times) == 0 {
+		return 0
+	}
+
+	var sum time.Duration
+	for _, t := range r.Times {
+		sum += t
+	}
+
+	return sum / time.Duration(len(r.Times))
+}
+
+// Get the standard deviation of the solve times of the runs
+func (r RunStats) StdDev() time.Duration {
+	if len(r.Times) < 2 {
+		return 0
+	}
+
+	mean := float64(r.Mean())
+	var squares float64
+	for _, t := range r.Times {
+		squares += (float64(t) - mean) * (float64(t) - mean)
+	}
+
+	return time.Duration(math.Sqrt(squares / float64(len(r.Times)-1)))
+}
+
+// Solve m with algo until there are runs solves in total, first being the result of the first one. Each run uses a new
+// solver with h and opts, one after the other so they don't disturb each other's timing. The runs stop early once ctx
+// is done
+func Repeat(ctx context.Context, m *maze.Maze, algo maze.Algo, h Heuristic, opts []Option, first maze.Result, runs int) (RunStats, error) {
+	stats := RunStats{Times: []time.Duration{first.SolveTime}, SamePath: true}
+	for len(stats.Times) < runs && ctx.Err() == nil {
+		solver, err := NewSolverForAlgo(algo, m, h, opts...)
+		if err != nil {
+			return stats, err
+		}
+
+		result, _ := solver.SolveContext(ctx)
+		if ctx.Err() != nil {
+			break
+		}
+
+		stats.Times = append(stats.Times, result.SolveTime)
+		if !slices.Equal(result.Solution.Path, first.Solution.Path) {
+			stats.SamePath = false
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,307 @@
+package solve
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// A state of the searched graph: a square of the maze, a puzzle board, a junction of a road network...
+type State interface {
+	comparable
+}
+
+// One move out of a state
+type Successor[S State] struct {
+	State  S
+	Action string
+	Cost   int // Cost of moving into State
+}
+
+// Any graph that the search algorithms can walk through. The maze grid is one of them (see Grid)
+type Graph[S State] interface {
+	// Append the moves out of state to buf and return the extended buf. The search gives back the same buf on every
+	// call, so an expansion allocates nothing for the successors once buf is large enough
+	AppendSuccessors(state S, buf []Successor[S]) []Successor[S]
+}
+
+// A graph that knows how many states it has, which the search uses as a safety net: it stops with
+// ErrSearchInvariant instead of expanding more states than that
+type FiniteGraph[S State] interface {
+	Graph[S]
+	States() int
+}
+
+// Returned when a search expands more states than its graph has (see FiniteGraph), which only a bug can do: a
+// successor that isn't a state of the graph, or a state expanded twice without reopening. It holds the sizes of the
+// search when it was stopped, so the bug can be looked into
+type ErrSearchInvariant struct {
+	States    int // Number of states of the graph
+	Expanded  int // Nodes expanded, a reopened state counts once per expansion
+	Explored  int // Distinct states expanded
+	Frontier  int // Nodes waiting in the frontier
+	Generated int // Nodes added to the frontier
+}
+
+func (err ErrSearchInvariant) Error() string {
+	return fmt.Sprintf("search stopped after expanding more states than the graph has: %d states, %d nodes expanded, "+
+		"%d distinct states explored, %d nodes in the frontier, %d generated", err.States, err.Expanded, err.Explored,
+		err.Frontier, err.Generated)
+}
+
+// A node of the search tree
+type Node[S State] struct {
+	State  S
+	Parent *Node[S]
+	Action string // Action taken from the parent to reach this node
+	Cost   int    // Used to order the node in a priority frontier, it depend on which algorithm you use
+	Order  int    // How many nodes were generated before this one, used to break ties in a fixed order
+
+	PathCost int // Cost of the path from the root to this node (g), whatever the algorithm
+	Estimate int // Estimated cost from this node to a goal (h), 0 when the search has no Estimate
+
+	index int // Position in a PriorityFrontier, so its cost can be updated
+	tree  int // Position in the Tree of the search once expanded
+}
+
+// The set of nodes waiting to be expanded. Which node comes out first is what makes the search a BFS, a DFS or a best-first search
+type Frontier[S State] interface {
+	Push(node *Node[S])
+	Pop() *Node[S]
+	Len() int
+	Contains(state S) bool
+	Nodes() []*Node[S] // The nodes in the order they are stored. Pushing them in this order into an empty frontier gives the same frontier
+}
+
+// A frontier where the cost of a node can be lowered. When the search finds a cheaper route to a state of such a
+// frontier, it moves the node onto that route instead of keeping the first route found
+type UpdatableFrontier[S State] interface {
+	Frontier[S]
+	Get(state S) *Node[S]           // The node of state in the frontier, nil if there is none
+	Update(node *Node[S], cost int) // Change the cost of a node of the frontier
+}
+
+// The search engine shared by every algorithm. The algorithms only differ on the frontier, how the cost of a new
+// node is calculated and whether the search goes depth-first
+type Search[S State] struct {
+	Graph      Graph[S]
+	Frontier   Frontier[S]
+	IsGoal     func(state S) bool
+	Estimate   func(state S) int       // Estimated cost from a state to a goal, recorded on the nodes. Nil means 0
+	Cost       func(node *Node[S]) int // Cost of a new node from its PathCost and Estimate, which are set first. The cost stays 0 if nil
+	DepthFirst bool                    // Only add the first new successor, and backtrack when there is none
+	Reopen     bool                    // Expand a state again when a cheaper path to it is found after its expansion
+
+	MaxNodes    int           // Stop with ErrLimitExceeded once this many nodes are expanded, reopened ones included, 0 means no limit
+	MaxDuration time.Duration // Stop with ErrLimitExceeded once the search has run this long, 0 means no limit
+
+	OnExpand func(node *Node[S], frontier, explored int) // Called every time a node is taken out of the frontier
+	OnPush   func(node *Node[S], frontier int)           // Called every time a node is added to the frontier
+	OnStep   func(state S, frontier int)                 // Called every time the current state changes, including backtracking
+
+	Explored     []S        // Every expanded state, in expansion order
+	Tree         []*Node[S] // Every expanded node, in expansion order
+	Generated    int        // Number of nodes added to the frontier
+	FrontierPeak int        // Maximum size of the frontier
+
+	explored   map[S]*Node[S] // The last expanded node of each state
+	nodes      nodeArena[S]
+	successors []Successor[S] // Reused by every expansion
+}
+
+// How many nodes are allocated at once by the search
+const arenaChunk = 1024
+
+// Allocate the nodes of a search by chunks instead of one by one. Every node of a search stays in the search tree or the
+// frontier until the search is dropped, so there is nothing to give back
+type nodeArena[S State] struct {
+	chunk []Node[S]
+}
+
+func (a *nodeArena[S]) new() *Node[S] {
+	if len(a.chunk) == 0 {
+		a.chunk = make([]Node[S], arenaChunk)
+	}
+
+	node := &a.chunk[0]
+	a.chunk = a.chunk[1:]
+	return node
+}
+
+// Search from start until a goal state is expanded. Return the goal node, which can be backtracked to get the path,
+// ErrNoSolution if every reachable state has been explored, ErrLimitExceeded once a limit is hit,
+// ErrSearchInvariant if the search is broken, or the context error once ctx is done. A search stopped by a limit or ctx can be carried on with Continue
+func (s *Search[S]) Run(ctx context.Context, start S) (*Node[S], error) {
+	s.explored = map[S]*Node[S]{}
+	s.Explored = nil
+	s.Tree = nil
+	s.Generated = 0
+	s.FrontierPeak = 0
+
+	// Create the start node and add it to the frontier
+	root := s.nodes.new()
+	root.State = start
+	if s.Estimate != nil {
+		root.Estimate = s.Estimate(start)
+	}
+	if s.Cost != nil {
+		root.Cost = s.Cost(root)
+	}
+	s.push(root)
+	s.step(start)
+
+	return s.Continue(ctx)
+}
+
+// Carry on a search started with Run or restored with Restore, from where it has stopped
+func (s *Search[S]) Continue(ctx context.Context) (*Node[S], error) {
+	began := time.Now()
+	states := 0
+	if g, ok := s.Graph.(FiniteGraph[S]); ok {
+		states = g.States()
+	}
+
+	// Make an infinite loop until we found the solution, or stop because we explored all states without finding a solution
+	for {
+		// Stop if the search is cancelled or has run out of time
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Stop if the search has hit one of its limits
+		if s.MaxNodes > 0 && len(s.Tree) >= s.MaxNodes {
+			return nil, ErrLimitExceeded{MaxNodes: s.MaxNodes}
+		}
+		if s.MaxDuration > 0 && time.Since(began) >= s.MaxDuration {
+			return nil, ErrLimitExceeded{MaxDuration: s.MaxDuration}
+		}
+
+		// If frontier is empty (which should mean that we have explored every path possible), return
+		if s.Frontier.Len() == 0 {
+			return nil, ErrNoSolution
+		}
+
+		// Get the current node (by pulling the node from the frontier)
+		current := s.Frontier.Pop()
+		current.tree = len(s.Tree)
+		if s.OnExpand != nil {
+			s.OnExpand(current, s.Frontier.Len(), len(s.Explored)+1)
+		}
+		s.step(current.State)
+		// A reopened state is only listed once in Explored, but every expansion is in the tree
+		if s.explored[current.State] == nil {
+			s.Explored = append(s.Explored, current.State)
+		}
+		s.explored[current.State] = current
+		s.Tree = append(s.Tree, current)
+
+		// Only a broken search can explore more states than the graph has, or expand a state twice without reopening
+		if states > 0 && (len(s.Explored) > states || !s.Reopen && len(s.Tree) > states) {
+			return nil, ErrSearchInvariant{States: states, Expanded: len(s.Tree), Explored: len(s.Explored),
+				Frontier: s.Frontier.Len(), Generated: s.Generated}
+		}
+
+		if s.IsGoal(current.State) {
+			return current, nil
+		}
+
+		// If we go into a state that there is no new state to explore (no successor get added to the frontier),
+		// a depth-first search has to backtrack to a place that has new path to move. Back at the root, every state
+		// reachable from it has been explored, and the empty frontier ends the search
+		for !s.expand(current) && s.DepthFirst {
+			if current.Parent == nil {
+				break
+			}
+			current = current.Parent
+			s.step(current.State)
+		}
+	}
+}
+
+// Add the successors of node that are neither in the frontier nor explored, or explored through a more expensive path
+// when the search reopens states. A depth-first search only adds the first one. Return whether any successor was added
+func (s *Search[S]) expand(node *Node[S]) bool {
+	added := false
+	s.successors = s.Graph.AppendSuccessors(node.State, s.successors[:0])
+	for _, next := range s.successors {
+		if closed := s.explored[next.State]; closed != nil && !(s.Reopen && node.PathCost+next.Cost < closed.PathCost) {
+			continue
+		}
+
+		if s.Frontier.Contains(next.State) {
+			s.reroute(node, next)
+			continue
+		}
+
+		child := s.nodes.new()
+		child.State, child.Parent, child.Action = next.State, node, next.Action
+		child.PathCost = node.PathCost + next.Cost
+		if s.Estimate != nil {
+			child.Estimate = s.Estimate(next.State)
+		}
+		if s.Cost != nil {
+			child.Cost = s.Cost(child)
+		}
+		s.push(child)
+		added = true
+
+		if s.DepthFirst {
+			break
+		}
+	}
+
+	return added
+}
+
+// Move the frontier node of next onto the route through parent if it's cheaper, when the frontier allows it
+func (s *Search[S]) reroute(parent *Node[S], next Successor[S]) {
+	frontier, ok := s.Frontier.(UpdatableFrontier[S])
+	if !ok || s.Cost == nil {
+		return
+	}
+
+	// The estimate only depends on the state, so the route is cheaper when its cost is lower
+	node := frontier.Get(next.State)
+	route := Node[S]{State: next.State, PathCost: parent.PathCost + next.Cost, Estimate: node.Estimate}
+	if cost := s.Cost(&route); cost < node.Cost {
+		node.Parent = parent
+		node.Action = next.Action
+		node.PathCost = route.PathCost
+		frontier.Update(node, cost)
+	}
+}
+
+func (s *Search[S]) push(node *Node[S]) {
+	node.Order = s.Generated
+	s.Frontier.Push(node)
+	s.Generated++
+	s.FrontierPeak = max(s.FrontierPeak, s.Frontier.Len())
+	if s.OnPush != nil {
+		s.OnPush(node, s.Frontier.Len())
+	}
+}
+
+func (s *Search[S]) step(state S) {
+	if s.OnStep != nil {
+		s.OnStep(state, s.Frontier.Len())
+	}
+}
+
+// Backtrack from node to the root of the search tree. Return the actions taken and the states visited, the root excluded
+func Backtrack[S State](node *Node[S]) ([]string, []S) {
+	var (
+		actions []string
+		path    []S
+	)
+
+	// Backtracking gives the path from the end, reversed once it's complete
+	for ; node != nil && node.Parent != nil; node = node.Parent {
+		actions = append(actions, node.Action)
+		path = append(path, node.State)
+	}
+	slices.Reverse(actions)
+	slices.Reverse(path)
+
+	return actions, path
+}
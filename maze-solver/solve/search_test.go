@@ -0,0 +1,72 @@
+package solve
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// A heuristic given square by square, 0 for the squares it doesn't list
+type tableHeuristic map[maze.Point]float64
+
+func (tableHeuristic) Name() string { return "table" }
+
+func (h tableHeuristic) Estimate(from, to maze.Point) float64 {
+	return h[from]
+}
+
+// A* reopens a square when a cheaper path to it is found after its expansion. In testdata/reopen.txt, the heuristic is
+// exact on (0, 2) and (1, 1) and 0 elsewhere: admissible but not consistent. A* expands (1, 2) first through the
+// bottom row with g = 8, then finds it through (0, 2) with g = 5. Without reopening it, the path costs 22 instead of 19
+func TestAStarReopensCheaperPath(t *testing.T) {
+	m := loadTestMaze(t, "testdata/reopen.txt", nil)
+	h := tableHeuristic{{Row: 0, Col: 2}: 18, {Row: 1, Col: 1}: 14}
+
+	dijkstra := solveValid(t, NewDijkstraSolver(m), m)
+	astar := solveValid(t, NewAStarSolverWithHeuristic(m, h), m)
+	if dijkstra.PathCost != 19 || astar.PathCost != 19 {
+		t.Fatalf("paths cost %d with Dijkstra and %d with A*, want 19", dijkstra.PathCost, astar.PathCost)
+	}
+
+	want := []maze.Point{{Row: 0, Col: 2}, {Row: 1, Col: 2}, {Row: 1, Col: 1}, {Row: 1, Col: 0}, {Row: 0, Col: 0}}
+	if !slices.Equal(astar.Solution.Path, want) {
+		t.Errorf("got path %v, want %v", astar.Solution.Path, want)
+	}
+
+	reopened := maze.Point{Row: 1, Col: 2}
+	expansions := 0
+	for _, node := range astar.SearchTree {
+		if node.Square.Coordinate == reopened {
+			expansions++
+		}
+	}
+	if expansions != 2 {
+		t.Errorf("%v is expanded %d times, want 2", reopened, expansions)
+	}
+	if listed := len(slices.DeleteFunc(slices.Clone(astar.Explored), func(p maze.Point) bool { return p != reopened })); listed != 1 {
+		t.Errorf("the reopened square %v is listed %d times in Explored, want 1", reopened, listed)
+	}
+}
+
+// The node limit counts the expansions like Result.Expanded, so a reopened square counts each time it's expanded. A*
+// expands 8 nodes on testdata/reopen.txt, 7 distinct squares
+func TestMaxNodesCountsReopenedNodes(t *testing.T) {
+	m := loadTestMaze(t, "testdata/reopen.txt", nil)
+	h := tableHeuristic{{Row: 0, Col: 2}: 18, {Row: 1, Col: 1}: 14}
+
+	full := solveValid(t, NewAStarSolverWithHeuristic(m, h), m)
+	if full.Expanded != 8 || len(full.Explored) != 7 {
+		t.Fatalf("%d nodes expanded for %d squares, want 8 for 7", full.Expanded, len(full.Explored))
+	}
+
+	limit := full.Expanded - 1
+	result, err := NewAStarSolverWithHeuristic(m, h, WithMaxNodes(limit)).Solve()
+	if !errors.Is(err, ErrLimitExceeded{MaxNodes: limit}) {
+		t.Fatalf("got error %v with a limit of %d nodes, want the node limit", err, limit)
+	}
+	if result.Expanded != limit {
+		t.Errorf("%d nodes expanded with a limit of %d", result.Expanded, limit)
+	}
+}
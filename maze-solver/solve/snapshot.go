@@ -0,0 +1,205 @@
+package solve
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Returned by Snapshot when there is no solve to carry on: none has been started, or the last one has finished
+var ErrNothingToSnapshot = errors.New("no unfinished solve to snapshot")
+
+// A node of a saved search, its parent is referenced by its index in SearchState.Nodes (-1 for none)
+type SavedNode[S State] struct {
+	State  S      `json:"state"`
+	Parent int    `json:"parent"`
+	Action string `json:"action"`
+	Cost   int    `json:"cost"`
+	Order  int    `json:"order"`
+
+	PathCost int `json:"path_cost"`
+	Estimate int `json:"estimate,omitempty"`
+}
+
+// Everything needed to carry on a search later: the search tree, the frontier and the counters
+type SearchState[S State] struct {
+	Nodes        []SavedNode[S] `json:"nodes"`    // The expanded nodes in expansion order, then the frontier nodes in frontier order
+	Expanded     int            `json:"expanded"` // How many of Nodes are expanded
+	Generated    int            `json:"generated"`
+	FrontierPeak int            `json:"frontier_peak"`
+}
+
+// Save the state of the search, so it can be restored later (even by another process) with Restore
+func (s *Search[S]) Save() SearchState[S] {
+	state := SearchState[S]{Expanded: len(s.Tree), Generated: s.Generated, FrontierPeak: s.FrontierPeak}
+
+	index := make(map[*Node[S]]int)
+	for _, node := range append(append([]*Node[S]{}, s.Tree...), s.Frontier.Nodes()...) {
+		parent, ok := index[node.Parent]
+		if !ok {
+			parent = -1
+		}
+
+		index[node] = len(state.Nodes)
+		state.Nodes = append(state.Nodes, SavedNode[S]{
+			State:  node.State,
+			Parent: parent,
+			Action: node.Action,
+			Cost:   node.Cost,
+			Order:  node.Order,
+
+			PathCost: node.PathCost,
+			Estimate: node.Estimate,
+		})
+	}
+
+	return state
+}
+
+// Restore a saved search. The graph, the functions and an empty frontier must be set first, Continue then carries on
+// the search from where it was saved. The hooks are not called for the restored nodes
+func (s *Search[S]) Restore(state SearchState[S]) error {
+	if state.Expanded < 0 || state.Expanded > len(state.Nodes) {
+		return fmt.Errorf("saved search has %d expanded nodes out of %d", state.Expanded, len(state.Nodes))
+	}
+
+	s.explored = map[S]*Node[S]{}
+	s.Explored = nil
+	s.Tree = nil
+	s.Generated = state.Generated
+	s.FrontierPeak = state.FrontierPeak
+
+	nodes := make([]*Node[S], len(state.Nodes))
+	for i, saved := range state.Nodes {
+		node := &Node[S]{State: saved.State, Action: saved.Action, Cost: saved.Cost, Order: saved.Order,
+			PathCost: saved.PathCost, Estimate: saved.Estimate}
+		if saved.Parent >= 0 {
+			// Only an expanded node can be a parent
+			if saved.Parent >= min(i, state.Expanded) {
+				return fmt.Errorf("saved node %d has parent %d, which is not expanded before it", i, saved.Parent)
+			}
+			node.Parent = nodes[saved.Parent]
+		}
+		nodes[i] = node
+
+		if i < state.Expanded {
+			if s.explored[node.State] == nil {
+				s.Explored = append(s.Explored, node.State)
+			}
+			s.explored[node.State] = node
+			node.tree = len(s.Tree)
+			s.Tree = append(s.Tree, node)
+		} else {
+			s.Frontier.Push(node)
+		}
+	}
+
+	return nil
+}
+
+// Version of the snapshot format, bumped on incompatible changes
+const snapshotVersion = 2
+
+// The snapshot of a maze solver: a gzipped JSON document with the saved search, and the trace and stats recorded so far
+type snapshot struct {
+	Version        int                     `json:"version"`
+	Algorithm      maze.Algo               `json:"algorithm"`
+	Width          int                     `json:"width"`
+	Height         int                     `json:"height"`
+	Start          maze.Point              `json:"start"`
+	Goal           maze.Point              `json:"goal"`
+	Search         SearchState[maze.Point] `json:"search"`
+	ExperimentPath []maze.Point            `json:"experiment_path"`
+	Counters       []maze.StepCounter      `json:"counters"`
+	Enqueued       []maze.Point            `json:"enqueued,omitempty"`
+	Stats          maze.Stats              `json:"stats"`
+}
+
+// Save the state of the last solve, when it has been stopped before finishing (by a limit or its context), so it
+// can be carried on later with Resume. Call it once the solve has returned
+func (g *gridSolver) Snapshot() ([]byte, error) {
+	if g.search == nil || g.finished {
+		return nil, ErrNothingToSnapshot
+	}
+
+	snap := snapshot{
+		Version:        snapshotVersion,
+		Algorithm:      g.algo,
+		Width:          g.Maze.Width,
+		Height:         g.Maze.Height,
+		Start:          g.Maze.Start,
+		Goal:           g.Maze.Goal,
+		Search:         g.search.Save(),
+		ExperimentPath: g.result.ExperimentPath,
+		Counters:       g.result.Counters,
+		Enqueued:       g.result.Enqueued,
+		Stats:          g.result.Stats,
+	}
+
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	if err := json.NewEncoder(zw).Encode(snap); err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Load a snapshot taken with Snapshot, the next solve carries on from it instead of starting over.
+// The snapshot must come from the same algorithm on the same maze
+func (g *gridSolver) Resume(data []byte) error {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %v", err)
+	}
+	defer zr.Close()
+
+	var snap snapshot
+	if err := json.NewDecoder(zr).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %v", err)
+	}
+
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+
+	if snap.Algorithm != g.algo {
+		return fmt.Errorf("the snapshot was taken with %s, not %s", snap.Algorithm, g.algo)
+	}
+
+	if snap.Width != g.Maze.Width || snap.Height != g.Maze.Height || snap.Start != g.Maze.Start || snap.Goal != g.Maze.Goal {
+		return fmt.Errorf("the snapshot was taken on a different maze")
+	}
+
+	// The solution is backtracked from the saved nodes, so every one of them has to be reached by a move of the maze
+	grid := &Grid{Maze: g.Maze}
+	nodes := snap.Search.Nodes
+	for i, saved := range nodes {
+		if !g.Maze.IsOpen(saved.State) {
+			return fmt.Errorf("saved node %d (%d, %d) is not an open square of the maze", i, saved.State.Row, saved.State.Col)
+		}
+
+		if saved.Parent < 0 {
+			if saved.State != g.Maze.Start {
+				return fmt.Errorf("saved node %d (%d, %d) has no parent but isn't the start", i, saved.State.Row, saved.State.Col)
+			}
+			continue
+		}
+		if saved.Parent >= len(nodes) || !slices.ContainsFunc(grid.AppendSuccessors(nodes[saved.Parent].State, nil),
+			func(next Successor[maze.Point]) bool { return next.State == saved.State && next.Action == saved.Action }) {
+			return fmt.Errorf("saved node %d (%d, %d) isn't reached from its parent by the move %q", i, saved.State.Row,
+				saved.State.Col, saved.Action)
+		}
+	}
+
+	g.resume = &snap
+	return nil
+}
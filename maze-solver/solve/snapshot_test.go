@@ -0,0 +1,53 @@
+package solve
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// The maze of mazes/maze.txt, which the seed corpus under testdata was saved on
+const snapshotMaze = "##B   #\n##9## #\n#4 #2 #\n# ## ##\n3    ##\nA######"
+
+// Resume never panics on the JSON of a snapshot, and a solve carried on from a snapshot it accepts ends with an error
+// or a valid solution. The seed corpus holds the snapshots of BFS, DFS and A* stopped after 4 nodes
+func FuzzResume(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m := &maze.Maze{}
+		if err := m.Load(snapshotMaze); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+
+		for _, algo := range AllAlgos {
+			solver, err := NewSolverForAlgo(algo, m, nil, WithMaxNodes(1000))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := solver.Resume(buf.Bytes()); err != nil {
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			result, err := solver.SolveContext(ctx)
+			cancel()
+			if errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("%s: the resumed solve hasn't finished in time", algo)
+			}
+			if err == nil {
+				if err := maze.VerifySolution(m, result.Solution); err != nil {
+					t.Fatalf("%s: the resumed solve has found an invalid solution: %v", algo, err)
+				}
+			}
+		}
+	})
+}
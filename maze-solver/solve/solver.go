@@ -0,0 +1,52 @@
+// Package solve implements the maze search algorithms (DFS, BFS, Dijkstra, GBFS and A*) on top of a generic
+// graph search, which can also be used on graphs other than the maze.
+package solve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+)
+
+// Returned by Solve when the goal can't be reached from the start. Solve also returns maze.ErrInvalidMaze and
+// maze.ErrUnsupportedCost when the maze can't be solved, ErrLimitExceeded and the context errors when it's stopped
+var ErrNoSolution = errors.New("no path exists from start to goal")
+
+// Universal interface for maze-solver. Every solver runs the generic Search on the maze Grid, and never modifies the maze
+type Solver interface {
+	Solve() (maze.Result, error)                           // Solve the maze, the result is returned even if there is no solution
+	SolveContext(ctx context.Context) (maze.Result, error) // Same as Solve, but stops once ctx is done
+	OnExpand(hook func(ev ExpandEvent))                    // Set the callback of every expanded node
+	OnStep(hook maze.StepHook)                             // Set the callback of every step taken
+	Events() <-chan SearchEvent                            // Get the events of the next solve, as an alternative to the callbacks
+	Snapshot() ([]byte, error)                             // Save the state of a stopped solve, to carry it on later
+	Resume(snapshot []byte) error                          // Make the next solve carry on from a snapshot
+}
+
+// Create the solver of algo. h is the heuristic of GBFS and A*, nil means the default of each algorithm
+func NewSolverForAlgo(algo maze.Algo, m *maze.Maze, h Heuristic, opts ...Option) (Solver, error) {
+	switch algo {
+	case maze.DFS:
+		return NewDFSSolver(m, opts...), nil
+	case maze.BFS:
+		return NewBFSSolver(m, opts...), nil
+	case maze.DIJKSTRA:
+		return NewDijkstraSolver(m, opts...), nil
+	case maze.PARALLEL_DIJKSTRA:
+		return NewParallelDijkstraSolver(m, opts...), nil
+	case maze.GBFS:
+		if h == nil {
+			return NewGBFSSolver(m, opts...), nil
+		}
+		return NewGBFSSolverWithHeuristic(m, h, opts...), nil
+	case maze.ASTAR:
+		if h == nil {
+			return NewAStarSolver(m, opts...), nil
+		}
+		return NewAStarSolverWithHeuristic(m, h, opts...), nil
+	}
+
+	return nil, fmt.Errorf("unsupported algorithm %q", algo)
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Artifacts written into a local directory
+type Local struct {
+	Dir string // The directory the names are relative to
+	URL string // The URL of Dir, without the trailing slash
+}
+
+// Write data into the file name of the directory, creating its parent directories
+func (l *Local) Put(ctx context.Context, name, contentType string, data []byte) (string, error) {
+	clean := path.Clean("/" + name)[1:]
+	if clean == "" || clean != name {
+		return "", fmt.Errorf("invalid artifact name %q", name)
+	}
+
+	file := filepath.Join(l.Dir, filepath.FromSlash(clean))
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return "", err
+	}
+
+	// Written aside and renamed, so a download never sees half a file
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	return l.URL + "/" + clean, nil
+}
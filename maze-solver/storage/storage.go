@@ -0,0 +1,65 @@
+// Package storage writes the artifacts of the solves (images, animations, exports) somewhere they can be downloaded
+// from: a local directory or an S3-compatible bucket
+package storage
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Where artifacts are written. Names are slash separated paths, e.g. jobs/1f2e/maze_astar.png
+type Storage interface {
+	// Write data as name, replacing what was there, and get the URL it can be downloaded from
+	Put(ctx context.Context, name, contentType string, data []byte) (string, error)
+}
+
+// Open the storage of spec: a local directory (dir:path, or just the path), or an S3 bucket (s3://bucket/prefix).
+// publicURL is the URL the artifacts are downloaded from, the name of an artifact is appended to it. When empty, a
+// directory gives /artifacts/ URLs, to be served by the server, and a bucket gives the URLs of its endpoint.
+// The bucket is configured by the environment: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_REGION (us-east-1 by
+// default) and S3_ENDPOINT (the AWS endpoint of the region by default, set it for MinIO and other compatible stores)
+func Open(spec, publicURL string) (Storage, error) {
+	switch {
+	case spec == "":
+		return nil, fmt.Errorf("no storage given")
+	case strings.HasPrefix(spec, "s3://"):
+		bucket, prefix, _ := strings.Cut(strings.TrimPrefix(spec, "s3://"), "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("no bucket in storage %q, expected s3://bucket/prefix", spec)
+		}
+
+		region := os.Getenv("AWS_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		endpoint := os.Getenv("S3_ENDPOINT")
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+
+		s3 := &S3{
+			Endpoint:  strings.TrimSuffix(endpoint, "/"),
+			Bucket:    bucket,
+			Prefix:    strings.Trim(prefix, "/"),
+			Region:    region,
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			PublicURL: strings.TrimSuffix(publicURL, "/"),
+		}
+		if s3.AccessKey == "" || s3.SecretKey == "" {
+			return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are needed by storage %q", spec)
+		}
+
+		return s3, nil
+	default:
+		dir := strings.TrimPrefix(spec, "dir:")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+
+		return &Local{Dir: dir, URL: strings.TrimSuffix(cmp.Or(publicURL, "/artifacts"), "/")}, nil
+	}
+}
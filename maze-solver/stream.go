@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// A message of the WebSocket of /solve/stream. The first one is a "maze" message with the size, start and goal of the
+// maze, then there is one "expand" or "enqueue" message per event of the solve, then a "done" message with the path
+// and the statistics. An "error" message is sent instead when the maze or the solve fails
+type streamMessage struct {
+	Kind     string       `json:"kind"`
+	Point    *maze.Point  `json:"point,omitempty"`    // The expanded or enqueued square
+	Frontier int          `json:"frontier,omitempty"` // Number of nodes in the frontier after the event
+	Explored int          `json:"explored,omitempty"` // Number of nodes expanded so far
+	Height   int          `json:"height,omitempty"`
+	Width    int          `json:"width,omitempty"`
+	Start    *maze.Point  `json:"start,omitempty"`
+	Goal     *maze.Point  `json:"goal,omitempty"`
+	Path     []maze.Point `json:"path,omitempty"` // The solution, empty if the goal can't be reached
+	Stats    *maze.Stats  `json:"stats,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// Send a message as JSON
+func (c *wsConn) writeMessage(msg streamMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return c.WriteText(data)
+}
+
+// Open a WebSocket, read the maze text from its first message, and stream the events of the solve while solving. The
+// solve stops when the client goes away
+func (cfg ServeConfig) handleStream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	algo, topology, h, err := solveParams(query.Get("algo"), query.Get("movement"), query.Get("heuristic"))
+	if err != nil {
+		cfg.metrics.failed("websocket", reasonBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := upgradeWebSocket(w, r, cfg.Origins)
+	if errors.Is(err, errForbiddenOrigin) {
+		cfg.metrics.failed("websocket", reasonForbiddenOrigin)
+		return
+	} else if err != nil {
+		cfg.metrics.failed("websocket", reasonBadRequest)
+		return
+	}
+
+	// The maze has to come before the timeout too, and a client that stops reading can't hold the solve longer
+	conn.conn.SetReadDeadline(time.Now().Add(cfg.Timeout))
+	conn.conn.SetWriteDeadline(time.Now().Add(2 * cfg.Timeout))
+	data, err := conn.ReadMessage(cfg.MaxBytes)
+	switch {
+	case errors.Is(err, errMessageTooLarge):
+		cfg.metrics.failed("websocket", reasonTooLarge)
+		conn.Close(wsCloseTooLarge, fmt.Sprintf("maze is larger than %d bytes", cfg.MaxBytes))
+		return
+	case err != nil:
+		cfg.metrics.failed("websocket", reasonBadRequest)
+		conn.Close(wsCloseProtocol, err.Error())
+		return
+	}
+	conn.conn.SetReadDeadline(time.Time{})
+
+	fail := func(err error) {
+		conn.writeMessage(streamMessage{Kind: "error", Error: err.Error()})
+		conn.Close(wsCloseNormal, "")
+	}
+
+	m, err := cfg.loadMaze(r.Context(), string(data), topology)
+	var tooMany maze.ErrTooLarge
+	if errors.As(err, &tooMany) {
+		cfg.metrics.failed("websocket", reasonTooLarge)
+		fail(err)
+		return
+	} else if err != nil {
+		cfg.metrics.failed("websocket", reasonBadRequest)
+		fail(err)
+		return
+	}
+
+	solver, err := solve.NewSolverForAlgo(algo, m, h, solve.WithoutTrace())
+	if err != nil {
+		cfg.metrics.failed("websocket", reasonBadRequest)
+		fail(err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	// Reading is what notices the client closing the connection, which stops the solve
+	go func() {
+		for {
+			if _, err := conn.ReadMessage(cfg.MaxBytes); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	type solveResult struct {
+		result maze.Result
+		err    error
+	}
+	events := solver.Events()
+	finished := make(chan solveResult, 1)
+	go func() {
+		result, err := solver.SolveContext(ctx)
+		finished <- solveResult{result, err}
+	}()
+
+	conn.writeMessage(streamMessage{Kind: "maze", Height: m.Height, Width: m.Width, Start: &m.Start, Goal: &m.Goal})
+
+	// The events are read until the channel is closed, even once the client is gone, so the solver never waits
+	for ev := range events {
+		if ev.Kind == solve.EventDone || ctx.Err() != nil {
+			continue
+		}
+
+		if err := conn.writeMessage(streamMessage{Kind: string(ev.Kind), Point: &ev.Point, Frontier: ev.Frontier,
+			Explored: ev.Explored}); err != nil {
+			cancel()
+		}
+	}
+
+	done := <-finished
+	cfg.metrics.solved("websocket", algo, done.result, done.err)
+	var invalid maze.ErrInvalidMaze
+	var cost maze.ErrUnsupportedCost
+	switch {
+	case errors.As(done.err, &invalid), errors.As(done.err, &cost):
+		fail(done.err)
+		return
+	case errors.Is(done.err, context.DeadlineExceeded):
+		fail(fmt.Errorf("solving took longer than %s", cfg.Timeout))
+		return
+	case errors.Is(done.err, context.Canceled):
+		conn.Close(wsCloseNormal, "")
+		return
+	case done.err != nil && !errors.Is(done.err, solve.ErrNoSolution):
+		fail(done.err)
+		return
+	}
+
+	LOGGER.Info("Maze solved over WebSocket", "algo", algo, "second(s)", done.result.SolveTime.Seconds(),
+		"expanded", done.result.Expanded, "path_cost", done.result.PathCost)
+	conn.writeMessage(streamMessage{Kind: "done", Explored: done.result.Expanded, Path: done.result.Solution.Path,
+		Stats: &done.result.Stats})
+	conn.Close(wsCloseNormal, "")
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// A server side WebSocket connection (RFC 6455), enough to stream messages to browsers: no extensions, no subprotocols
+type wsConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	mu   sync.Mutex // Held while writing a frame, pongs are written by the reader
+}
+
+var (
+	// Returned by ReadMessage when a message is larger than its limit
+	errMessageTooLarge = errors.New("message too large")
+
+	// Returned by upgradeWebSocket when the handshake comes from a web page of an origin that isn't allowed
+	errForbiddenOrigin = errors.New("WebSocket origin not allowed")
+)
+
+// The opcodes of the frames
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xa
+)
+
+// The close codes used by the server
+const (
+	wsCloseNormal   = 1000
+	wsCloseProtocol = 1002
+	wsCloseTooLarge = 1009
+)
+
+// Added to the key of the client to get the accept key of the handshake
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Check if a header has token in its comma separated list, ignoring the case
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for part := range strings.SplitSeq(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Check if the WebSocket handshake r may be answered: browsers send the origin of the page opening the WebSocket, which
+// has to be the one of the server, or one of allowed, a comma separated list of origins ("*" allows any). Requests
+// without an Origin don't come from a browser and are allowed
+func originAllowed(r *http.Request, allowed string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	for a := range strings.SplitSeq(allowed, ",") {
+		if a = strings.TrimSpace(a); a == "*" || strings.EqualFold(a, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Answer the WebSocket handshake of r and take over its connection, if it comes from an origin of allowed (see
+// originAllowed). The error has already been answered to the client when the request isn't a valid handshake or its
+// origin isn't allowed
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request, allowed string) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !headerHasToken(r.Header, "Connection", "upgrade") || !headerHasToken(r.Header, "Upgrade", "websocket") || key == "" {
+		err := errors.New("not a WebSocket handshake")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, err
+	}
+	if !originAllowed(r, allowed) {
+		http.Error(w, errForbiddenOrigin.Error(), http.StatusForbidden)
+		return nil, errForbiddenOrigin
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		err := errors.New("unsupported WebSocket version")
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, err.Error(), http.StatusUpgradeRequired)
+		return nil, err
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, err
+	}
+
+	sum := sha1.Sum([]byte(key + wsGUID))
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %s\r\n\r\n", base64.StdEncoding.EncodeToString(sum[:]))
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, rw: rw}, nil
+}
+
+// Write a single frame, unmasked as every frame of a server
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+
+	return c.rw.Flush()
+}
+
+// Send a text message
+func (c *wsConn) WriteText(message []byte) error {
+	return c.writeFrame(wsText, message)
+}
+
+// Read the next text or binary message, of at most limit bytes. The pings are answered while reading. io.EOF is
+// returned once the client has closed the connection
+func (c *wsConn) ReadMessage(limit int64) ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame(limit - int64(len(message)))
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case wsPing:
+			if err := c.writeFrame(wsPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			c.writeFrame(wsClose, payload)
+			return nil, io.EOF
+		case wsText, wsBinary:
+			if message != nil {
+				return nil, errors.New("new message before the end of the previous one")
+			}
+			message = payload
+		case wsContinuation:
+			if message == nil {
+				return nil, errors.New("continuation frame without a message")
+			}
+			message = append(message, payload...)
+		default:
+			return nil, fmt.Errorf("unknown opcode %d", opcode)
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// Read a frame of at most limit bytes, or of at most 125 bytes if it's a control frame, and unmask its payload
+func (c *wsConn) readFrame(limit int64) (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.rw, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, opcode = header[0]&0x80 != 0, header[0]&0x0f
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, errors.New("unmasked frame from the client")
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if opcode >= wsClose && (length > 125 || !fin) {
+		return false, 0, nil, errors.New("invalid control frame")
+	}
+	if opcode < wsClose && length > uint64(max(limit, 0)) {
+		return false, 0, nil, errMessageTooLarge
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// Send a close frame with code and reason, and close the connection
+func (c *wsConn) Close(code uint16, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, code)
+	c.writeFrame(wsClose, append(payload, reason...))
+	return c.conn.Close()
+}
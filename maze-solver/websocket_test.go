@@ -0,0 +1,55 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A WebSocket of /solve/stream is only opened by the web pages of the server and of -origins, or without an Origin.
+// {host} is replaced by the address of the server
+func TestSolveStreamOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origins string
+		origin  string
+		want    int
+	}{
+		{"no origin", "", "", http.StatusSwitchingProtocols},
+		{"same origin", "", "http://{host}", http.StatusSwitchingProtocols},
+		{"other origin", "", "https://evil.example", http.StatusForbidden},
+		{"other host port", "", "http://127.0.0.1:1", http.StatusForbidden},
+		{"allowed origin", "https://app.example, https://good.example", "https://good.example", http.StatusSwitchingProtocols},
+		{"not in the allowed", "https://good.example", "https://evil.example", http.StatusForbidden},
+		{"any origin", "*", "https://evil.example", http.StatusSwitchingProtocols},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(NewServer(ServeConfig{Timeout: 5 * time.Second, MaxBytes: 1 << 20, Origins: tt.origins}))
+			defer srv.Close()
+
+			req, err := http.NewRequest(http.MethodGet, srv.URL+"/solve/stream", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Upgrade", "websocket")
+			req.Header.Set("Sec-WebSocket-Version", "13")
+			req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			if tt.origin != "" {
+				req.Header.Set("Origin", strings.ReplaceAll(tt.origin, "{host}", req.URL.Host))
+			}
+
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
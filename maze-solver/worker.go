@@ -0,0 +1,216 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/danglnh07/go-ai/maze-solver/maze"
+	"github.com/danglnh07/go-ai/maze-solver/render"
+	"github.com/danglnh07/go-ai/maze-solver/solve"
+)
+
+// Options of the worker command
+type WorkerConfig struct {
+	URL         string        // The NATS server
+	Subject     string        // Where the solve requests are consumed from
+	Queue       string        // The queue group of the workers, each request goes to one of them
+	Results     string        // Where the answers of the requests without a reply subject are published, none when empty
+	Concurrency int           // Requests solved at the same time
+	Timeout     time.Duration // Stop each solve after this long
+	MaxCells    int           // Most squares (height x width) of a maze accepted, 0 means no limit
+}
+
+// A solve request consumed by the worker, the parameters of POST /solve as JSON
+type workerRequest struct {
+	ID        string `json:"id,omitempty"` // Copied to the answer, to match it with the request
+	Maze      string `json:"maze"`
+	Algo      string `json:"algo,omitempty"`
+	Heuristic string `json:"heuristic,omitempty"`
+	Movement  string `json:"movement,omitempty"`
+}
+
+// The answer of a solve request
+type workerReply struct {
+	ID     string          `json:"id,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"` // The JSON export of the solve, like POST /solve answers
+}
+
+// The subscription id of the solve requests
+const workerSID = 1
+
+// Run the worker command: solve the requests of a NATS subject until Ctrl+C, reconnecting when the connection is lost
+func Worker(args []string) error {
+	cfg := WorkerConfig{}
+	fs := newFlagSet("worker", "", "Solve the mazes of the requests published on a NATS subject, so the solves can be spread\n"+
+		"over a fleet of stateless workers. Every worker joins the same queue group, so each request is solved once.\n"+
+		"A request is a JSON object with the maze text and the parameters of POST /solve:\n"+
+		"  {\"id\": \"42\", \"maze\": \"#####\\n#A B#\\n#####\", \"algo\": \"astar\", \"heuristic\": \"manhattan\", \"movement\": \"four\"}\n"+
+		"The answer, {\"id\": \"42\", \"result\": <the JSON export of the solve>} or {\"id\": \"42\", \"error\": \"...\"}, is\n"+
+		"published on the reply subject of the request (so nats request works), or on -results when it has none.\n"+
+		"On Ctrl+C, the worker leaves the queue group and finishes the solves it has received before exiting.")
+	fs.StringVar(&cfg.URL, "nats", "nats://127.0.0.1:4222", "The NATS server, nats://[user:pass@ or token@]host:port")
+	fs.StringVar(&cfg.Subject, "subject", "maze.solve", "The subject the solve requests are consumed from")
+	fs.StringVar(&cfg.Queue, "queue", "maze-solver", "The queue group shared by the workers")
+	fs.StringVar(&cfg.Results, "results", "maze.results", "Where the answers without a reply subject go, dropped when empty")
+	fs.IntVar(&cfg.Concurrency, "concurrency", 1, "How many requests are solved at the same time")
+	fs.DurationVar(&cfg.Timeout, "timeout", 30*time.Second, "Stop each solve after this long")
+	fs.IntVar(&cfg.MaxCells, "max-cells", 4_000_000, "The most squares (height x width) of a maze accepted, 0 means no limit")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	// The first connection is not retried, so a wrong URL is reported right away
+	wait := time.Second
+	for first := true; ; first = false {
+		connected, err := cfg.consume(ctx)
+		if ctx.Err() != nil {
+			return nil
+		} else if first && !connected {
+			return err
+		}
+
+		if connected {
+			wait = time.Second
+		}
+		LOGGER.Warn("Lost the NATS connection, reconnecting", "error", err, "in", wait)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+		wait = min(2*wait, 30*time.Second)
+	}
+}
+
+// Connect, and solve the requests until ctx is done or the connection is lost. connected tells if the connection has
+// been made
+func (cfg WorkerConfig) consume(ctx context.Context) (connected bool, err error) {
+	nc, err := dialNATS(ctx, cfg.URL, "maze-solver worker")
+	if err != nil {
+		return false, err
+	}
+	defer nc.Close()
+
+	if err := nc.Subscribe(cfg.Subject, cfg.Queue, workerSID); err != nil {
+		return true, err
+	}
+	LOGGER.Info("Consuming solve requests", "subject", cfg.Subject, "queue", cfg.Queue, "concurrency", cfg.Concurrency)
+
+	// Once done, stop receiving and let the solves of the messages received so far end
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			LOGGER.Info("Draining the solve requests")
+			nc.Drain(workerSID)
+		case <-done:
+		}
+	}()
+
+	msgs := make(chan natsMsg)
+	wg := sync.WaitGroup{}
+	for range max(cfg.Concurrency, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range msgs {
+				cfg.answer(nc, msg)
+			}
+		}()
+	}
+
+	for {
+		msg, err := nc.Next()
+		if err != nil {
+			close(msgs)
+			wg.Wait()
+			if errors.Is(err, errNATSDrained) {
+				return true, nil
+			}
+			return true, err
+		}
+		msgs <- msg
+	}
+}
+
+// Solve the request of msg and publish the answer
+func (cfg WorkerConfig) answer(nc *natsConn, msg natsMsg) {
+	subject := cmp.Or(msg.Reply, cfg.Results)
+	reply := cfg.solve(msg.Data)
+	if subject == "" {
+		return
+	}
+
+	data, err := json.Marshal(reply)
+	if err == nil {
+		err = nc.Publish(subject, data)
+	}
+	if errors.Is(err, errNATSPayloadTooLarge) {
+		data, _ = json.Marshal(workerReply{ID: reply.ID, Error: "the result is larger than the max payload of the NATS server"})
+		err = nc.Publish(subject, data)
+	}
+	if err != nil {
+		LOGGER.Warn("Failed to publish an answer", "id", reply.ID, "subject", subject, "error", err)
+	}
+}
+
+// Solve a request and get its answer
+func (cfg WorkerConfig) solve(data []byte) workerReply {
+	var req workerRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return workerReply{Error: fmt.Sprintf("invalid request: %v", err)}
+	}
+	reply := workerReply{ID: req.ID}
+
+	algo, topology, h, err := solveParams(req.Algo, req.Movement, req.Heuristic)
+	if err != nil {
+		reply.Error = err.Error()
+		return reply
+	}
+	m, err := ServeConfig{MaxCells: cfg.MaxCells}.loadMaze(context.Background(), req.Maze, topology)
+	if err != nil {
+		reply.Error = err.Error()
+		return reply
+	}
+	solver, err := solve.NewSolverForAlgo(algo, m, h, solve.WithoutTrace())
+	if err != nil {
+		reply.Error = err.Error()
+		return reply
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	result, err := solver.SolveContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		reply.Error = fmt.Sprintf("solving took longer than %s", cfg.Timeout)
+		return reply
+	} else if err != nil && !errors.Is(err, solve.ErrNoSolution) {
+		reply.Error = err.Error()
+		return reply
+	}
+
+	solved := &maze.Solved{Maze: m, SearchType: algo, Result: result}
+	if h := solve.HeuristicOf(solver); h != nil {
+		solved.Heuristic = h.Name()
+	}
+	buf, err := render.CreateJSON(cmp.Or(req.ID, "request"), []*maze.Solved{solved})
+	if err != nil {
+		reply.Error = err.Error()
+		return reply
+	}
+	LOGGER.Info("Maze solved from NATS", "id", req.ID, "algo", algo, "second(s)", result.SolveTime.Seconds(),
+		"expanded", result.Expanded, "path_cost", result.PathCost)
+
+	reply.Result = buf.Bytes()
+	return reply
+}